@@ -0,0 +1,53 @@
+package main
+
+import (
+	"flag"
+	"math/rand"
+	"os"
+	"time"
+
+	"github.com/serhatsdev/sudoku/game"
+	"github.com/serhatsdev/sudoku/game/board"
+	"github.com/serhatsdev/sudoku/game/export"
+)
+
+// runBooklet runs the booklet subcommand, it generates puzzles
+// and writes them with their answers to a PDF file
+func runBooklet(args []string) error {
+	flags := flag.NewFlagSet("booklet", flag.ExitOnError)
+	count := flags.Int("count", 12, "number of puzzles")
+	difficultyName := flags.String("difficulty", "medium", "difficulty: beginner, easy, medium, hard or very-hard")
+	symmetryName := flags.String("symmetry", "rotational", "clue layout: none, rotational, mirror or diagonal")
+	size := flags.Int("size", 9, "rows and columns of the boards: 4, 6, 9, 12 or 16")
+	perPage := flags.Int("per-page", 4, "puzzles per page: 1, 2, 4 or 6")
+	output := flags.String("o", "sudoku.pdf", "output file")
+	seed := flags.Int64("seed", time.Now().UnixNano(), "seed of the puzzle generator, the same seed prints the same puzzles")
+	flags.Parse(args)
+
+	difficulty, err := game.ParseDifficulty(*difficultyName)
+	if err != nil {
+		return err
+	}
+
+	symmetry, err := board.ParseSymmetry(*symmetryName)
+	if err != nil {
+		return err
+	}
+
+	dims, err := board.DimensionsOf(*size)
+	if err != nil {
+		return err
+	}
+
+	random := rand.New(rand.NewSource(*seed))
+	puzzles := []board.Board{}
+	for i := 0; i < *count; i++ {
+		puzzles = append(puzzles, board.New(dims, difficulty, symmetry, random))
+	}
+
+	data, err := export.Booklet("Sudoku - "+game.DifficultyName(difficulty), puzzles, *perPage)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(*output, data, 0644)
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/serhatsdev/sudoku/game"
+	"github.com/serhatsdev/sudoku/game/export"
+)
+
+// runExport runs the export subcommand, it exports the given puzzle
+// or the most recently played game to the standard output or a file
+func runExport(args []string) error {
+	flags := flag.NewFlagSet("export", flag.ExitOnError)
+	formatName := flags.String("format", "unicode", "export format: line, ascii, unicode, markdown, html or svg")
+	output := flags.String("o", "", "write to the given file instead of the standard output")
+	notes := flags.Bool("notes", false, "export the notes of the empty cells")
+	puzzleOnly := flags.Bool("puzzle-only", false, "export only the predefined values")
+	puzzle := flags.String("puzzle", "", "export the given puzzle instead of the last game")
+	file := flags.String("file", "", "export the puzzle in the given file instead of the last game")
+	flags.Parse(args)
+
+	format, err := export.ParseFormat(*formatName)
+	if err != nil {
+		return err
+	}
+
+	b, err := importPuzzle(*puzzle, *file)
+	if err != nil {
+		return err
+	}
+	if b == nil {
+		savedata, err := game.LoadLastSavedGame()
+		if err != nil {
+			return err
+		}
+		b = savedata.Board
+	}
+
+	data, err := export.Export(b, format, export.Options{Notes: *notes, PuzzleOnly: *puzzleOnly})
+	if err != nil {
+		return err
+	}
+	if *output == "" {
+		fmt.Print(data)
+		return nil
+	}
+	return os.WriteFile(*output, []byte(data), 0644)
+}
@@ -0,0 +1,127 @@
+package board
+
+import "sync"
+
+// baseGridLines are complete grids in the line format that were searched
+// ahead of time, so no grid is searched while a puzzle is generated.
+// Every grid has the constraints of the grid rules in its comment.
+var baseGridLines = []string{
+	// 4x4 Diagonal, Windoku
+	"1234341243212143",
+	// 4x4 Windoku, Anti-Knight
+	"1234432134122143",
+	// 6x6 Diagonal
+	"123456465213312564654321531642246135",
+	// 6x6 Non-Consecutive
+	"135246462513246351513624351462624135",
+	// 6x6 Windoku, Anti-Knight
+	"123456654321561234432165345612216543",
+	// 6x6 Windoku, Anti-King
+	"123456645132536241214563462315351624",
+	// 9x9 Diagonal, Anti-Knight
+	"349762581867513294215894376158926437934175628726438915472689153683251749591347862",
+	// 9x9 Windoku, Anti-Knight
+	"123456789589317246476289513241598637695731428837624951314962875952873164768145392",
+	// 9x9 Diagonal, Non-Consecutive
+	"135279684468513927792846351927468513351792846684135279846351792279684135513927468",
+	// 9x9 Diagonal, Windoku, Anti-King
+	"123456789895273164674198253538624971417985326962317845341862597259741638786539412",
+	// 9x9 Anti-Knight, Anti-King, Non-Consecutive
+	"147582936582936471936471825471825369825369714369714258714258693258693147693147582",
+	// 12x12 Diagonal, Anti-Knight
+	"123456789ABCCB684A391257A957C12B436883127564A9CB764BA9C185235C9A83B26714B5C93846217A4A219C57B8363786B21AC49598752BAC364164AC17935B8221B364857CA9",
+	// 12x12 Windoku, Non-Consecutive
+	"1352B7AC8496497A25861C3BC6B8413975A26135C8B4A27984971A52C6B3AC2B36974815258193CA6B4797A46B25318C3B6C8471592AB246AC1397585813796B2AC47AC95248B361",
+	// 12x12 Diagonal, Windoku, Anti-King
+	"415C2689B37AA9275BC38164386B41A759C29C13A7642B585476821BAC938BA2953C741673C514B862A926487C9A153BBA916325C847628AC94137B51539B8764A2CC7B43A529681",
+	// 12x12 Windoku, Anti-Knight, Anti-King
+	"2C45B3816A978637CA294B519BA14675382C45829B1AC673C3765842B91AB19A3C678542745B29A31C68A86C71B49235321985C6A7B467B4A23851C95AC3179B24861928645C73AB",
+	// 12x12 Anti-Knight, Anti-King, Non-Consecutive
+	"164B53A8297C53A8297C64B1297C64B13A8564B13A8597C23A8597C24B1697C24B16A8534B16A8537C29A8537C29B1647C29B164853AB164853AC297853AC297164BC297164B53A8",
+	// 16x16 Windoku
+	"A3216BF7DE485CG995G8DCE2673FB4A1DECF1G43BA596728B7649A851G2C3EDF42BA3ECF9D618G577F5CB29GA8E4163D39D684A1527GFBCE81EG756DFCB3924A1C4BF6G839DAE572FA352974EBG6D81C6D87E35BC412A9FGEG92AD1C85F7436BG67DC8BA4F9521E35BFE412973CDGA86281357DEG6ABCF94C4A9GF36218E7DB5",
+	// 16x16 Diagonal, Anti-Knight, Anti-King, Non-Consecutive
+	"14253697A8BECFDG3697A8BECFDG1425A8BECFDG14253697CFDG14253697A8BE69738BEAFDGC42518BEAFDGC42516973FDGC425169738BEA425169738BEAFDGCBEA8DGCF25149736DGCF25149736BEA825149736BEA8DGCF9736BEA8DGCF2514GCFD51427369EA8B51427369EA8BGCFD7369EA8BGCFD5142EA8BGCFD51427369",
+}
+
+// impossibleRules are the smallest combinations of the grid rules that no
+// grid of the dimensions has, the whole search found no grid with them
+var impossibleRules = map[Dimensions][]Rule{
+	{2, 2}: {AntiKingRule, NonConsecutiveRule, DiagonalRule | AntiKnightRule},
+	{3, 2}: {
+		DiagonalRule | WindokuRule, DiagonalRule | AntiKnightRule, DiagonalRule | AntiKingRule,
+		AntiKnightRule | AntiKingRule, DiagonalRule | NonConsecutiveRule, WindokuRule | NonConsecutiveRule,
+		AntiKnightRule | NonConsecutiveRule, AntiKingRule | NonConsecutiveRule,
+	},
+	Classic: {
+		WindokuRule | AntiKnightRule | NonConsecutiveRule, WindokuRule | AntiKingRule | NonConsecutiveRule,
+		DiagonalRule | AntiKnightRule | AntiKingRule | NonConsecutiveRule,
+	},
+}
+
+type baseGridKey struct {
+	dims  Dimensions
+	rules Rule
+}
+
+// baseGrid is the base grid of some rules, err
+// tells why there is none if grid is nil
+type baseGrid struct {
+	grid Grid
+	err  error
+}
+
+var (
+	baseGridsMutex sync.Mutex
+	// baseGrids are the base grids looked up for the rules
+	baseGrids = map[baseGridKey]baseGrid{}
+)
+
+// SupportsRules returns if boards of the given dimensions
+// can be generated with the given rules, see ValidateRules
+func SupportsRules(dims Dimensions, rules Rule) bool {
+	return ValidateRules(dims, rules) == nil
+}
+
+// ValidateRules returns ErrUnsupportedRules if the given rules can not be
+// combined on boards of the given dimensions, such as anti-king on 4x4,
+// and ErrGridNotFound if the search found no grid with them
+func ValidateRules(dims Dimensions, rules Rule) error {
+	_, err := getBaseGrid(dims, rules)
+	return err
+}
+
+// getBaseGrid returns the first of the base grids with the constraints
+// of the given rules. The grids are looked up once and shared.
+func getBaseGrid(dims Dimensions, rules Rule) (Grid, error) {
+	key := baseGridKey{dims, rules & gridRules}
+
+	baseGridsMutex.Lock()
+	defer baseGridsMutex.Unlock()
+
+	base, exist := baseGrids[key]
+	if !exist {
+		base.grid, base.err = findBaseGrid(key.dims, key.rules)
+		baseGrids[key] = base
+	}
+	return base.grid, base.err
+}
+
+// findBaseGrid returns the first of baseGridLines
+// with the given dimensions and grid rules
+func findBaseGrid(dims Dimensions, rules Rule) (Grid, error) {
+	for _, impossible := range impossibleRules[dims] {
+		if rules.Has(impossible) {
+			return nil, ErrUnsupportedRules
+		}
+	}
+
+	constraints := getConstraints(rules)
+	for _, line := range baseGridLines {
+		grid, err := ParseLine(line)
+		if err == nil && grid.Size() == dims.Size() && ValidateConstraints(grid, constraints...) == nil {
+			return grid, nil
+		}
+	}
+	return nil, ErrGridNotFound
+}
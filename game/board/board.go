@@ -1,17 +1,21 @@
 package board
 
-import (
-	"math/rand"
-	"time"
-)
+import "math/rand"
 
-// Board Difficulty
+// Board Difficulty, the number of cells removed from a complete 9x9 grid,
+// the other sizes remove the same share of their cells. The 9x9 puzzles
+// are graded by the logic solver and cells are removed until the rating
+// is in the band of the difficulty, see ratingBands, so they can have
+// more removed cells, or fewer if the solution would not be unique.
 const (
 	Beginner = byte(20)
 	Easy     = byte(30)
 	Medium   = byte(40)
 	Hard     = byte(50)
 	VeryHard = byte(60)
+
+	// Custom is the difficulty of the boards that are not generated
+	Custom = byte(0)
 )
 
 // Point2 is a position in a 2d array
@@ -19,6 +23,9 @@ type Point2 struct{ X, Y int }
 
 // Board is an interface for a sudoku board
 type Board interface {
+	// Dimensions returns the sizes of the board and its boxes
+	Dimensions() Dimensions
+
 	// Get returns the cell value at the given position
 	Get(pos Point2) int
 
@@ -34,41 +41,306 @@ type Board interface {
 	IsCorrect(pos Point2) bool
 
 	// GetConflicts returns positions of cells that has the given value
-	// in row, column and subsquare of the given position
+	// in row, column and subsquare of the given position, and the
+	// positions of cells that break the constraints of the board
+	// with the given value, see Constraint.Conflicts
 	GetConflicts(pos Point2, value int) map[Point2]struct{}
 
+	// GetPeers returns positions of cells in row, column and subsquare
+	// of the given position, and the peers of the constraints of the board
+	GetPeers(pos Point2) map[Point2]struct{}
+
+	// Constraints returns the constraints of the board
+	// on top of the rows, columns and boxes
+	Constraints() []Constraint
+
+	// Rules returns the rules of the constraints of the board
+	Rules() Rule
+
+	// Cages returns the cages of a killer board, nil for the other boards
+	Cages() Cages
+
+	// GetCage returns the cage of the cell at the given position
+	// and if the cell is in a cage
+	GetCage(pos Point2) (Cage, bool)
+
+	// GetRegion returns the index of the box of the cell at the
+	// given position, the boxes of the jigsaw boards are their regions
+	GetRegion(pos Point2) int
+
 	// GetPositions returns positions of cells
 	// that has the given value in complete board
 	GetPositions(value int) map[Point2]struct{}
+
+	// ToggleNote adds the value to the notes of the cell,
+	// or removes it if the cell already has it
+	ToggleNote(pos Point2, value int)
+
+	// ClearNotes removes all notes of the cell
+	ClearNotes(pos Point2)
+
+	// GetNotes returns the note values of the cell in ascending order
+	GetNotes(pos Point2) []int
+
+	// IsComplete returns if every cell has a value
+	IsComplete() bool
+
+	// IsSolved returns if every cell has the correct value
+	IsSolved() bool
+}
+
+// maxGenerateAttempts is the number of puzzles New tries
+// when the requested difficulty can not be reached
+const maxGenerateAttempts = 100
+
+// maxUniquenessSteps limits the uniqueness checks of the boards
+// larger than 9x9, checking sparse large grids can take minutes
+const maxUniquenessSteps = 20000
+
+// ratingBands are the accepted logic solver ratings of each difficulty,
+// the bands do not overlap so the hardest technique a puzzle
+// needs decides its difficulty
+var ratingBands = map[byte][2]int{
+	Beginner: {0, HiddenSingle.Weight()},
+	Easy:     {NakedSingle.Weight(), NakedSingle.Weight()},
+	Medium:   {Pointing.Weight(), BoxLineReduction.Weight()},
+	Hard:     {NakedPair.Weight(), XYWing.Weight()},
+	VeryHard: {Swordfish.Weight(), GuessRating},
+}
+
+// getRatingDistance returns how far the rating is
+// from the rating band of the given difficulty
+func getRatingDistance(difficulty byte, rating int) int {
+	band, exist := ratingBands[difficulty]
+	if !exist {
+		return 0
+	}
+
+	if rating < band[0] {
+		return band[0] - rating
+	} else if rating > band[1] {
+		return rating - band[1]
+	}
+	return 0
+}
+
+// New returns a new board instance with a unique solution and the given
+// dimensions, which must be one of Sizes. difficulty is the number of
+// cells to remove from a complete 9x9 grid, more cells are removed
+// until the logic solver rating of the puzzle is in the rating band of
+// the difficulty and puzzles are generated until it is. If that can
+// not be reached, the closest puzzle is returned. The rating bands are made for 9x9
+// boards, the other sizes are not graded. The clues are laid out with
+// the given symmetry. The given random source decides which puzzle
+// is generated, see Generate to replay puzzles by id.
+func New(dims Dimensions, difficulty byte, symmetry Symmetry, random *rand.Rand) Board {
+	var complete, incomplete Grid
+	removedCount, distance := -1, -1
+	count := getRemoveCount(dims, difficulty)
+
+	attempts := maxGenerateAttempts
+	if dims != Classic {
+		attempts = 1
+	}
+
+	for i := 0; i < attempts && distance != 0; i++ {
+		cComplete := GenerateGrid(dims, random)
+		var cIncomplete Grid
+		var cRemovedCount int
+		if band, exist := ratingBands[difficulty]; exist && dims == Classic {
+			cIncomplete, cRemovedCount = removeCellsToBand(cComplete, count, band, symmetry, random)
+		} else {
+			cIncomplete, cRemovedCount = removeCells(cComplete, nil, count, symmetry, random)
+		}
+		cDistance := getRatingDistance(difficulty, GradeGrid(cIncomplete).Rating)
+
+		if distance == -1 || cDistance < distance ||
+			(cDistance == distance && cRemovedCount > removedCount) {
+			complete, incomplete = cComplete, cIncomplete
+			removedCount, distance = cRemovedCount, cDistance
+		}
+	}
+
+	return NewCustom(incomplete, complete, getPredefined(incomplete))
+}
+
+// maxVariantGridSteps limits the random searches of a complete grid with the
+// constraints of the rules, the search is tried maxVariantGridAttempts times
+// before the grid is made from the base grid of the rules
+const (
+	maxVariantGridSteps    = 20000
+	maxVariantGridAttempts = 3
+)
+
+// NewVariant returns a new board like New, with the constraints of the given
+// rules on top of the rows, columns and boxes. The puzzles of the variants
+// are not graded. It returns the error of ValidateRules if the rules
+// are not supported on the dimensions.
+func NewVariant(dims Dimensions, difficulty byte, symmetry Symmetry, rules Rule, random *rand.Rand) (Board, error) {
+	if rules == 0 {
+		return New(dims, difficulty, symmetry, random), nil
+	}
+	if err := ValidateRules(dims, rules); err != nil {
+		return nil, err
+	}
+
+	constraints := getConstraints(rules)
+	var complete Grid
+	if rules.Has(JigsawRule) {
+		var regions Regions
+		complete, regions = generateJigsaw(dims, rules, constraints, random)
+		constraints = append([]Constraint{regions}, constraints...)
+	} else {
+		complete = generateVariantGrid(dims, rules, constraints, random)
+	}
+
+	count := getRemoveCount(dims, difficulty)
+	if rules.Has(KillerRule) {
+		constraints = append(constraints, generateCages(complete, getMaxCageSize(difficulty), random))
+		count = getKillerRemoveCount(dims, difficulty)
+	}
+	if rules.Has(EvenOddRule) {
+		constraints = append(constraints, generateEvenOdd(complete, random))
+	}
+
+	incomplete, _ := removeCells(complete, constraints, count, symmetry, random)
+	return NewCustom(incomplete, complete, getPredefined(incomplete), constraints...), nil
 }
 
-// New returns a new board instance
-func New(difficulty byte) Board {
-	complete := GenerateGrid()
-	incomplete := Grid{}
-	predefined := [Size][Size]bool{}
+// generateVariantGrid returns a complete grid with the given constraints
+// of the given rules, which must be supported. The grids without
+// constraints are generated like the classic grids, so the killer
+// puzzle ids generate the same puzzles.
+func generateVariantGrid(dims Dimensions, rules Rule, constraints []Constraint, random *rand.Rand) Grid {
+	if len(constraints) == 0 {
+		return GenerateGrid(dims, random)
+	}
 
-	predefinedCellsCount := Size*Size - difficulty
+	for i := 0; i < maxVariantGridAttempts; i++ {
+		s, _ := newSolver(NewGrid(dims.Size()), constraints)
+		s.limit = 1
+		s.maxSteps = maxVariantGridSteps
+		s.random = random
+		s.search(0)
+		if s.count == 1 {
+			return s.solution
+		}
+	}
 
-	for predefinedCellsCount > 0 {
-		pos := randomPos()
-		if incomplete[pos.Y][pos.X] == 0 {
-			incomplete[pos.Y][pos.X] = complete[pos.Y][pos.X]
-			predefined[pos.Y][pos.X] = true
-			predefinedCellsCount--
+	base, _ := getBaseGrid(dims, rules)
+	return shuffleVariantGrid(base, dims, rules, constraints, random)
+}
+
+// getRemoveCount returns the number of cells the difficulty
+// removes from a board with the given dimensions
+func getRemoveCount(dims Dimensions, difficulty byte) int {
+	classicCells := Classic.Size() * Classic.Size()
+	return int(difficulty) * dims.Size() * dims.Size() / classicCells
+}
+
+// getPredefined returns the cells of the grid that have a value
+func getPredefined(grid Grid) [][]bool {
+	predefined := make([][]bool, grid.Size())
+	for i := range grid {
+		predefined[i] = make([]bool, grid.Size())
+		for j := range grid[i] {
+			predefined[i][j] = grid[i][j] != 0
 		}
 	}
+	return predefined
+}
+
+// removeCells removes up to count cells from the given complete grid
+// in random order, a cell is removed together with its symmetric cell
+// and only if the grid still has a unique solution with the given constraints.
+// It returns the grid and number of removed cells.
+func removeCells(complete Grid, constraints []Constraint, count int, symmetry Symmetry, random *rand.Rand) (Grid, int) {
+	grid := complete.Copy()
+	removedCount := 0
+
+	for _, pos := range randomPositions(grid.Size(), random) {
+		orbit := symmetry.getOrbit(pos, grid.Size())
+		if grid[pos.Y][pos.X] == 0 || removedCount+len(orbit) > count {
+			continue
+		}
+
+		for _, cPos := range orbit {
+			grid[cPos.Y][cPos.X] = 0
+		}
 
-	return NewCustom(incomplete, complete, predefined)
+		if !hasUniqueSolution(grid, constraints, getMaxUniquenessSteps(grid.Size())) {
+			for _, cPos := range orbit {
+				grid[cPos.Y][cPos.X] = complete[cPos.Y][cPos.X]
+			}
+			continue
+		}
+
+		removedCount += len(orbit)
+	}
+
+	return grid, removedCount
 }
 
-// NewCustom returns a new board instance with custom values
-func NewCustom(incomplete Grid, complete Grid, predefined [Size][Size]bool) Board {
-	board := &board{}
+// removeCellsToBand removes cells from the given complete 9x9 grid like
+// removeCells, the removals that rate the puzzle above the given rating
+// band are undone. After count cells it keeps removing cells until
+// the rating of the puzzle is in the band.
+func removeCellsToBand(complete Grid, count int, band [2]int, symmetry Symmetry, random *rand.Rand) (Grid, int) {
+	grid := complete.Copy()
+	removedCount, rating := 0, 0
+
+	for _, pos := range randomPositions(grid.Size(), random) {
+		if removedCount >= count && rating >= band[0] {
+			break
+		}
 
-	for i := 0; i < Size; i++ {
-		for j := 0; j < Size; j++ {
-			board[i][j] = cell{
+		orbit := symmetry.getOrbit(pos, grid.Size())
+		if grid[pos.Y][pos.X] == 0 {
+			continue
+		}
+
+		for _, cPos := range orbit {
+			grid[cPos.Y][cPos.X] = 0
+		}
+
+		cRating := -1
+		if hasUniqueSolution(grid, nil, 0) {
+			cRating = GradeGrid(grid).Rating
+		}
+		if cRating == -1 || cRating > band[1] {
+			for _, cPos := range orbit {
+				grid[cPos.Y][cPos.X] = complete[cPos.Y][cPos.X]
+			}
+			continue
+		}
+
+		removedCount += len(orbit)
+		rating = cRating
+	}
+
+	return grid, removedCount
+}
+
+// getMaxUniquenessSteps returns the limit of
+// the uniqueness checks of the given board size
+func getMaxUniquenessSteps(size int) int {
+	if size > Classic.Size() {
+		return maxUniquenessSteps
+	}
+	return 0
+}
+
+// NewCustom returns a new board instance with custom values and
+// the given constraints, the grids must have the same size,
+// which must be one of Sizes
+func NewCustom(incomplete Grid, complete Grid, predefined [][]bool, constraints ...Constraint) Board {
+	board := &board{layout: getConstraintLayout(complete.Size(), constraints), constraints: constraints}
+
+	board.cells = make([][]cell, complete.Size())
+	for i := range board.cells {
+		board.cells[i] = make([]cell, complete.Size())
+		for j := range board.cells[i] {
+			board.cells[i][j] = cell{
 				value:      incomplete[i][j],
 				predefined: predefined[i][j],
 				correct:    complete[i][j],
@@ -83,74 +355,122 @@ type cell struct {
 	value      int
 	predefined bool
 	correct    int
+	// notes is a bitset, bit n is set if n is noted
+	notes uint32
 }
 
-type board [Size][Size]cell
+type board struct {
+	layout      *layout
+	cells       [][]cell
+	constraints []Constraint
+}
+
+func (board *board) Dimensions() Dimensions {
+	return board.layout.dims
+}
 
 func (board *board) Get(pos Point2) int {
-	return board[pos.Y][pos.X].value
+	return board.cells[pos.Y][pos.X].value
 }
 
 func (board *board) Set(pos Point2, value int) {
 	if !board.IsPredefined(pos) {
-		board[pos.Y][pos.X].value = value
+		board.cells[pos.Y][pos.X].value = value
 	}
 }
 
 func (board *board) GetCorrect(pos Point2) int {
-	return board[pos.Y][pos.X].correct
+	return board.cells[pos.Y][pos.X].correct
 }
 
 func (board *board) IsPredefined(pos Point2) bool {
-	return board[pos.Y][pos.X].predefined
+	return board.cells[pos.Y][pos.X].predefined
 }
 
 func (board *board) IsCorrect(pos Point2) bool {
-	return board.Get(pos) == board[pos.Y][pos.X].correct
+	return board.Get(pos) == board.cells[pos.Y][pos.X].correct
 }
 
 func (board *board) GetConflicts(pos Point2, value int) map[Point2]struct{} {
 	values := map[Point2]struct{}{}
 
-	// check row
-	for i := 0; i < Size; i++ {
-		cPos := Point2{i, pos.Y}
+	for cPos := range board.GetPeers(pos) {
 		if board.Get(cPos) == value {
 			values[cPos] = struct{}{}
 		}
 	}
 
-	// check column
-	for i := 0; i < Size; i++ {
-		cPos := Point2{pos.X, i}
-		if board.Get(cPos) == value {
-			values[cPos] = struct{}{}
+	if len(board.constraints) > 0 {
+		grid := board.getGrid()
+		for _, constraint := range board.constraints {
+			for _, cPos := range constraint.Conflicts(grid, pos, value) {
+				values[cPos] = struct{}{}
+			}
 		}
 	}
 
-	// check subsquare
-	sPos := Point2{
-		X: int(pos.X/3) * 3,
-		Y: int(pos.Y/3) * 3,
-	}
-	for i := 0; i < 3; i++ {
-		for j := 0; j < 3; j++ {
-			cPos := Point2{sPos.X + j, sPos.Y + i}
-			if board.Get(cPos) == value {
-				values[cPos] = struct{}{}
-			}
+	return values
+}
+
+// getGrid returns the values of the board
+func (board *board) getGrid() Grid {
+	grid := NewGrid(board.layout.size)
+	for i := range grid {
+		for j := range grid[i] {
+			grid[i][j] = board.cells[i][j].value
 		}
 	}
+	return grid
+}
+
+func (board *board) GetPeers(pos Point2) map[Point2]struct{} {
+	values := map[Point2]struct{}{}
+	for _, peer := range board.layout.peers[pos.Y][pos.X] {
+		values[peer] = struct{}{}
+	}
 
-	delete(values, pos)
+	for _, constraint := range board.constraints {
+		for _, peer := range constraint.Peers(pos, board.layout.size) {
+			values[peer] = struct{}{}
+		}
+	}
 	return values
 }
 
+func (board *board) Constraints() []Constraint {
+	return board.constraints
+}
+
+func (board *board) Rules() Rule {
+	rules := Rule(0)
+	for _, constraint := range board.constraints {
+		rules |= constraint.Rule()
+	}
+	return rules
+}
+
+func (board *board) Cages() Cages {
+	for _, constraint := range board.constraints {
+		if cages, ok := constraint.(Cages); ok {
+			return cages
+		}
+	}
+	return nil
+}
+
+func (board *board) GetRegion(pos Point2) int {
+	return board.layout.regions[pos.Y][pos.X]
+}
+
+func (board *board) GetCage(pos Point2) (Cage, bool) {
+	return board.Cages().find(pos)
+}
+
 func (board *board) GetPositions(value int) map[Point2]struct{} {
 	values := map[Point2]struct{}{}
 
-	for i := 0; i < Size; i++ {
-		for j := 0; j < Size; j++ {
+	for i := 0; i < board.layout.size; i++ {
+		for j := 0; j < board.layout.size; j++ {
 			pos := Point2{j, i}
 			if board.Get(pos) == value {
 				values[pos] = struct{}{}
@@ -161,9 +481,42 @@ func (board *board) GetPositions(value int) map[Point2]struct{} {
 	return values
 }
 
-// randomPos returns a random position on the board
-func randomPos() Point2 {
-	rand.Seed(time.Now().UnixNano())
+func (board *board) ToggleNote(pos Point2, value int) {
+	if !board.IsPredefined(pos) && value > 0 && value <= board.layout.size {
+		board.cells[pos.Y][pos.X].notes ^= 1 << value
+	}
+}
+
+func (board *board) ClearNotes(pos Point2) {
+	board.cells[pos.Y][pos.X].notes = 0
+}
+
+func (board *board) GetNotes(pos Point2) []int {
+	return maskValues(board.cells[pos.Y][pos.X].notes)
+}
+
+func (board *board) IsComplete() bool {
+	return len(board.GetPositions(0)) == 0
+}
+
+func (board *board) IsSolved() bool {
+	for i := 0; i < board.layout.size; i++ {
+		for j := 0; j < board.layout.size; j++ {
+			if !board.IsCorrect(Point2{j, i}) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// randomPositions returns all positions on a board
+// of the given size in random order
+func randomPositions(size int, random *rand.Rand) []Point2 {
+	positions := []Point2{}
+	for _, i := range random.Perm(size * size) {
+		positions = append(positions, Point2{i % size, i / size})
+	}
 
-	return Point2{rand.Intn(Size), rand.Intn(Size)}
+	return positions
 }
@@ -1,6 +1,8 @@
 package board_test
 
 import (
+	"math/rand"
+	"reflect"
 	"testing"
 
 	"github.com/serhatsdev/sudoku/game/board"
@@ -30,7 +32,7 @@ func getBoard() board.Board {
 			{2, 1, 8, 5, 7, 9, 3, 6, 4},
 			{3, 4, 5, 6, 1, 8, 7, 2, 9},
 		},
-		[board.Size][board.Size]bool{
+		[][]bool{
 			{false, true, false, false, true, false, true, true, false},
 			{true, true, false, true, true, false, true, true, true},
 			{true, false, true, true, true, false, false, true, false},
@@ -47,21 +49,68 @@ func getBoard() board.Board {
 func TestNew(t *testing.T) {
 	tests := []struct {
 		difficulty byte
-		expected   int
+		minimum    int
+		minRating  int
+		maxRating  int
 	}{
-		{board.Beginner, 20},
-		{board.Easy, 30},
-		{board.Medium, 40},
-		{board.Hard, 50},
-		{board.VeryHard, 60},
+		{board.Beginner, 20, 0, board.HiddenSingle.Weight()},
+		{board.Easy, 30, board.NakedSingle.Weight(), board.NakedSingle.Weight()},
+		{board.Medium, 40, board.Pointing.Weight(), board.BoxLineReduction.Weight()},
+		{board.Hard, 45, board.NakedPair.Weight(), board.XYWing.Weight()},
+		{board.VeryHard, 45, board.Swordfish.Weight(), board.GuessRating},
 	}
 
+	random := rand.New(rand.NewSource(1))
 	for _, test := range tests {
-		board := board.New(test.difficulty)
-		actual := len(board.GetPositions(0))
-		if test.expected != actual {
-			t.Errorf("board.New(%d) failed: Expected: %d, Actual:%d",
-				test.difficulty, test.expected, actual)
+		tBoard := board.New(board.Classic, test.difficulty, board.NoSymmetry, random)
+		actual := len(tBoard.GetPositions(0))
+		if actual < test.minimum {
+			t.Errorf("board.New(%d) failed: Expected: at least %d removed, Actual:%d",
+				test.difficulty, test.minimum, actual)
+		}
+
+		if rating := board.GradeGrid(getGrid(tBoard)).Rating; rating < test.minRating || rating > test.maxRating {
+			t.Errorf("board.New(%d) failed: Expected rating: %d..%d, Actual:%d",
+				test.difficulty, test.minRating, test.maxRating, rating)
+		}
+
+		if count := board.CountSolutions(getGrid(tBoard), 2); count != 1 {
+			t.Errorf("board.New(%d) failed: Expected 1 solution, Actual:%d",
+				test.difficulty, count)
+		}
+	}
+}
+
+func TestNewSymmetry(t *testing.T) {
+	tests := []struct {
+		symmetry board.Symmetry
+		mirror   func(pos board.Point2) board.Point2
+	}{
+		{board.Rotational, func(pos board.Point2) board.Point2 {
+			return board.Point2{X: 8 - pos.X, Y: 8 - pos.Y}
+		}},
+		{board.Mirror, func(pos board.Point2) board.Point2 {
+			return board.Point2{X: 8 - pos.X, Y: pos.Y}
+		}},
+		{board.Diagonal, func(pos board.Point2) board.Point2 {
+			return board.Point2{X: pos.Y, Y: pos.X}
+		}},
+	}
+
+	random := rand.New(rand.NewSource(1))
+	for _, test := range tests {
+		tBoard := board.New(board.Classic, board.Medium, test.symmetry, random)
+
+		for pos := range tBoard.GetPositions(0) {
+			if other := test.mirror(pos); tBoard.Get(other) != 0 {
+				t.Errorf("board.New(%s) failed: Expected: %v empty, Actual:%d",
+					test.symmetry, other, tBoard.Get(other))
+			}
+		}
+
+		if count := board.CountSolutions(getGrid(tBoard), 2); count != 1 {
+			t.Errorf("board.New(%s) failed: Expected 1 solution, Actual:%d",
+				test.symmetry, count)
 		}
 	}
 }
@@ -283,6 +332,40 @@ func TestGetConflicts(t *testing.T) {
 	}
 }
 
+func TestGetPeers(t *testing.T) {
+	tBoard := getBoard()
+
+	tests := []struct {
+		pos      board.Point2
+		expected []board.Point2
+	}{
+		{
+			pos: board.Point2{0, 0},
+			expected: []board.Point2{
+				{1, 0}, {2, 0}, {3, 0}, {4, 0}, {5, 0}, {6, 0}, {7, 0}, {8, 0},
+				{0, 1}, {0, 2}, {0, 3}, {0, 4}, {0, 5}, {0, 6}, {0, 7}, {0, 8},
+				{1, 1}, {2, 1}, {1, 2}, {2, 2},
+			},
+		},
+		{
+			pos: board.Point2{4, 4},
+			expected: []board.Point2{
+				{0, 4}, {1, 4}, {2, 4}, {3, 4}, {5, 4}, {6, 4}, {7, 4}, {8, 4},
+				{4, 0}, {4, 1}, {4, 2}, {4, 3}, {4, 5}, {4, 6}, {4, 7}, {4, 8},
+				{3, 3}, {5, 3}, {3, 5}, {5, 5},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		actual := tBoard.GetPeers(test.pos)
+		if !equals(actual, test.expected) {
+			t.Errorf("board.GetPeers(%d,%d) failed! Map: %v",
+				test.pos.X, test.pos.Y, actual)
+		}
+	}
+}
+
 func TestGetPositions(t *testing.T) {
 	tBoard := getBoard()
 
@@ -333,6 +416,72 @@ func TestGetPositions(t *testing.T) {
 	}
 }
 
+func TestToggleNote(t *testing.T) {
+	tBoard := getBoard()
+	tBoard.ToggleNote(board.Point2{0, 0}, 4)
+	tBoard.ToggleNote(board.Point2{0, 0}, 1)
+	tBoard.ToggleNote(board.Point2{0, 0}, 6)
+	tBoard.ToggleNote(board.Point2{0, 0}, 4)
+	tBoard.ToggleNote(board.Point2{0, 0}, 10)
+	tBoard.ToggleNote(board.Point2{1, 0}, 3)
+
+	tests := []struct {
+		pos      board.Point2
+		expected []int
+	}{
+		{
+			pos:      board.Point2{0, 0},
+			expected: []int{1, 6},
+		},
+		{
+			pos:      board.Point2{1, 0},
+			expected: []int{},
+		},
+		{
+			pos:      board.Point2{8, 8},
+			expected: []int{},
+		},
+	}
+
+	for _, test := range tests {
+		actual := tBoard.GetNotes(test.pos)
+		if !reflect.DeepEqual(test.expected, actual) {
+			t.Errorf("board.GetNotes(%d,%d) failed: Expected: %v, Actual:%v",
+				test.pos.X, test.pos.Y, test.expected, actual)
+		}
+	}
+}
+
+func TestClearNotes(t *testing.T) {
+	tBoard := getBoard()
+	tBoard.ToggleNote(board.Point2{0, 0}, 4)
+	tBoard.ToggleNote(board.Point2{0, 0}, 1)
+	tBoard.ClearNotes(board.Point2{0, 0})
+
+	if actual := tBoard.GetNotes(board.Point2{0, 0}); len(actual) != 0 {
+		t.Errorf("board.ClearNotes(0,0) failed: Actual:%v", actual)
+	}
+}
+
+func TestIsCompleteAndSolved(t *testing.T) {
+	tBoard := getBoard()
+	if tBoard.IsComplete() || tBoard.IsSolved() {
+		t.Errorf("board is complete before filling")
+	}
+
+	for pos := range tBoard.GetPositions(0) {
+		tBoard.Set(pos, tBoard.GetCorrect(pos))
+	}
+	if !tBoard.IsComplete() || !tBoard.IsSolved() {
+		t.Errorf("board is not solved after filling correct values")
+	}
+
+	tBoard.Set(board.Point2{0, 0}, 1)
+	if !tBoard.IsComplete() || tBoard.IsSolved() {
+		t.Errorf("board is solved with a wrong value")
+	}
+}
+
 // ================== util functions =================
 func equals(pMap map[board.Point2]struct{}, pSlice []board.Point2) bool {
 	if len(pMap) != len(pSlice) {
@@ -348,3 +497,33 @@ func equals(pMap map[board.Point2]struct{}, pSlice []board.Point2) bool {
 
 	return true
 }
+
+func getGrid(tBoard board.Board) board.Grid {
+	grid := board.NewGrid(tBoard.Dimensions().Size())
+	for i := range grid {
+		for j := range grid[i] {
+			grid[i][j] = tBoard.Get(board.Point2{X: j, Y: i})
+		}
+	}
+	return grid
+}
+
+func getSolutionGrid(tBoard board.Board) board.Grid {
+	grid := board.NewGrid(tBoard.Dimensions().Size())
+	for i := range grid {
+		for j := range grid[i] {
+			grid[i][j] = tBoard.GetCorrect(board.Point2{X: j, Y: i})
+		}
+	}
+	return grid
+}
+
+func gridFromString(str string) board.Grid {
+	grid := board.NewGrid(9)
+	for i, char := range str {
+		if char >= '1' && char <= '9' {
+			grid[i/9][i%9] = int(char - '0')
+		}
+	}
+	return grid
+}
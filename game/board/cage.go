@@ -0,0 +1,263 @@
+package board
+
+import (
+	"errors"
+	"math/bits"
+	"math/rand"
+)
+
+// ErrInvalidCages is returned when the cages of a killer board
+// are out of the board, overlap or have sums that can not be reached
+var ErrInvalidCages = errors.New("killer cages are not valid")
+
+// Cage is a group of cells of a killer board,
+// the values of the cells add up to the sum
+// and a value can not repeat within the cage
+type Cage struct {
+	Sum   int
+	Cells []Point2
+}
+
+// Contains returns if the cell at the given position is in the cage
+func (cage Cage) Contains(pos Point2) bool {
+	return containsPos(cage.Cells, pos)
+}
+
+// Cages is the constraint of the killer boards
+type Cages []Cage
+
+func (Cages) Rule() Rule {
+	return KillerRule
+}
+
+func (cages Cages) Peers(pos Point2, size int) []Point2 {
+	peers := []Point2{}
+	if cage, exist := cages.find(pos); exist {
+		for _, cPos := range cage.Cells {
+			if cPos != pos {
+				peers = append(peers, cPos)
+			}
+		}
+	}
+	return peers
+}
+
+// Conflicts returns the cells of the cage that have the given value,
+// or all other cells with values if the given value breaks the sum of the cage
+func (cages Cages) Conflicts(grid Grid, pos Point2, value int) []Point2 {
+	cage, exist := cages.find(pos)
+	if !exist {
+		return []Point2{}
+	}
+
+	conflicts := getPeerConflicts(grid, cages.Peers(pos, grid.Size()), value)
+	if !breaksCageSum(grid, cage, pos, value) {
+		return conflicts
+	}
+
+	for _, cPos := range cage.Cells {
+		if cPos != pos && grid[cPos.Y][cPos.X] != 0 && !containsPos(conflicts, cPos) {
+			conflicts = append(conflicts, cPos)
+		}
+	}
+	return conflicts
+}
+
+// find returns the cage of the cell at the given position
+func (cages Cages) find(pos Point2) (Cage, bool) {
+	for _, cage := range cages {
+		if cage.Contains(pos) {
+			return cage, true
+		}
+	}
+	return Cage{}, false
+}
+
+// breaksCageSum returns if the cage values exceed the sum of the cage,
+// or do not add up to it when every cell has a value,
+// with the given value at the given position
+func breaksCageSum(grid Grid, cage Cage, pos Point2, value int) bool {
+	sum, complete := value, true
+	for _, cPos := range cage.Cells {
+		if cPos == pos {
+			continue
+		}
+
+		sum += grid[cPos.Y][cPos.X]
+		if grid[cPos.Y][cPos.X] == 0 {
+			complete = false
+		}
+	}
+
+	return sum > cage.Sum || (complete && sum != cage.Sum)
+}
+
+// ValidateCages returns ErrInvalidCages if the cages
+// do not fit a board with the given number of rows and columns
+func ValidateCages(cages Cages, size int) error {
+	_, err := getCageIndexes(cages, size)
+	return err
+}
+
+// getCageIndexes returns the index of the cage of every cell,
+// -1 for the cells without a cage
+func getCageIndexes(cages Cages, size int) ([][]int, error) {
+	indexes := make([][]int, size)
+	for i := range indexes {
+		indexes[i] = make([]int, size)
+		for j := range indexes[i] {
+			indexes[i][j] = -1
+		}
+	}
+
+	for i, cage := range cages {
+		if len(cage.Cells) == 0 || len(cage.Cells) > size {
+			return nil, ErrInvalidCages
+		}
+
+		for _, pos := range cage.Cells {
+			if pos.X < 0 || pos.Y < 0 || pos.X >= size || pos.Y >= size || indexes[pos.Y][pos.X] != -1 {
+				return nil, ErrInvalidCages
+			}
+			indexes[pos.Y][pos.X] = i
+		}
+
+		if getSumValues(cage.Sum, len(cage.Cells), getAllValuesMask(size)) == 0 {
+			return nil, ErrInvalidCages
+		}
+	}
+
+	return indexes, nil
+}
+
+// getSumValues returns the values of the given available values that
+// are in a combination of count different values adding up to the sum
+func getSumValues(sum, count int, available uint32) uint32 {
+	if count == 1 {
+		if sum > 0 && sum < 32 && available&(1<<sum) != 0 {
+			return 1 << sum
+		}
+		return 0
+	}
+
+	values := uint32(0)
+	for mask := available; mask != 0; mask &= mask - 1 {
+		// the other values are larger than the smallest value
+		value := bits.TrailingZeros32(mask)
+		if value*count > sum {
+			break
+		}
+
+		bit := uint32(1) << value
+		rest := getSumValues(sum-value, count-1, available&^(bit<<1-1))
+		if rest != 0 {
+			values |= bit | rest
+		}
+	}
+	return values
+}
+
+// killerCageSizes are the largest cages of each difficulty
+var killerCageSizes = map[byte]int{
+	Beginner: 2,
+	Easy:     3,
+	Medium:   3,
+	Hard:     4,
+	VeryHard: 5,
+}
+
+// getMaxCageSize returns the size of the largest cages
+// of the given difficulty
+func getMaxCageSize(difficulty byte) int {
+	if size, exist := killerCageSizes[difficulty]; exist {
+		return size
+	}
+	return killerCageSizes[Medium]
+}
+
+// getKillerRemoveCount returns the number of cells the difficulty removes
+// from a killer board, the cages allow removing more cells than the classic
+// boards and the hardest puzzles have no clues at all
+func getKillerRemoveCount(dims Dimensions, difficulty byte) int {
+	count := getRemoveCount(dims, difficulty) * 3 / 2
+	if cells := dims.Size() * dims.Size(); count > cells {
+		return cells
+	}
+	return count
+}
+
+// generateCages splits the complete grid into cages of neighbour cells
+// with different values, a cage has up to maxSize cells
+func generateCages(complete Grid, maxSize int, random *rand.Rand) Cages {
+	size := complete.Size()
+	indexes := make([][]int, size)
+	for i := range indexes {
+		indexes[i] = make([]int, size)
+		for j := range indexes[i] {
+			indexes[i][j] = -1
+		}
+	}
+
+	cages := Cages{}
+	for _, pos := range randomPositions(size, random) {
+		if indexes[pos.Y][pos.X] != -1 {
+			continue
+		}
+
+		cage := Cage{Cells: []Point2{pos}}
+		indexes[pos.Y][pos.X] = len(cages)
+		target := 2 + random.Intn(maxSize-1)
+
+		for len(cage.Cells) < target {
+			next := []Point2{}
+			for _, cPos := range cage.Cells {
+				for _, neighbour := range getNeighbours(cPos, size) {
+					if indexes[neighbour.Y][neighbour.X] == -1 && !containsPos(next, neighbour) &&
+						!containsValue(complete, cage.Cells, complete[neighbour.Y][neighbour.X]) {
+						next = append(next, neighbour)
+					}
+				}
+			}
+			if len(next) == 0 {
+				break
+			}
+
+			neighbour := next[random.Intn(len(next))]
+			indexes[neighbour.Y][neighbour.X] = len(cages)
+			cage.Cells = append(cage.Cells, neighbour)
+		}
+
+		cages = append(cages, cage)
+	}
+
+	for i := range cages {
+		for _, pos := range cages[i].Cells {
+			cages[i].Sum += complete[pos.Y][pos.X]
+		}
+	}
+
+	return cages
+}
+
+// getNeighbours returns the positions above, below,
+// left and right of the given position on the board
+func getNeighbours(pos Point2, size int) []Point2 {
+	neighbours := []Point2{}
+	for _, offset := range []Point2{{0, -1}, {0, 1}, {-1, 0}, {1, 0}} {
+		cPos := Point2{pos.X + offset.X, pos.Y + offset.Y}
+		if cPos.X >= 0 && cPos.Y >= 0 && cPos.X < size && cPos.Y < size {
+			neighbours = append(neighbours, cPos)
+		}
+	}
+	return neighbours
+}
+
+// containsValue returns if one of the given cells has the value
+func containsValue(grid Grid, positions []Point2, value int) bool {
+	for _, pos := range positions {
+		if grid[pos.Y][pos.X] == value {
+			return true
+		}
+	}
+	return false
+}
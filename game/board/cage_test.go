@@ -0,0 +1,154 @@
+package board_test
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/serhatsdev/sudoku/game/board"
+)
+
+func getKillerBoard() board.Board {
+	b := getBoard()
+	complete := board.NewGrid(9)
+	predefined := [][]bool{}
+	for i := range complete {
+		for j := range complete[i] {
+			complete[i][j] = b.GetCorrect(board.Point2{X: j, Y: i})
+		}
+		predefined = append(predefined, make([]bool, 9))
+	}
+
+	return board.NewCustom(board.NewGrid(9), complete, predefined, board.Cages{
+		// 6 2 4
+		{Sum: 12, Cells: []board.Point2{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 2, Y: 0}}},
+		// 7 5
+		{Sum: 12, Cells: []board.Point2{{X: 0, Y: 1}, {X: 1, Y: 1}}},
+	})
+}
+
+func TestNewVariantKiller(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	for _, dims := range board.Sizes {
+		tBoard, err := board.NewVariant(dims, board.Easy, board.NoSymmetry, board.KillerRule, random)
+		if err != nil {
+			t.Fatalf("board.NewVariant(%s) failed: %v", dims, err)
+		}
+		size := dims.Size()
+
+		covered := map[board.Point2]int{}
+		for _, cage := range tBoard.Cages() {
+			sum, values := 0, map[int]struct{}{}
+			for _, pos := range cage.Cells {
+				covered[pos]++
+				sum += tBoard.GetCorrect(pos)
+				values[tBoard.GetCorrect(pos)] = struct{}{}
+			}
+
+			if sum != cage.Sum || len(values) != len(cage.Cells) {
+				t.Errorf("board.NewVariant(%s) failed: cage %v does not match the solution", dims, cage)
+			}
+		}
+
+		if len(covered) != size*size {
+			t.Errorf("board.NewVariant(%s) failed: Expected: %d caged cells, Actual:%d",
+				dims, size*size, len(covered))
+		}
+		for pos, count := range covered {
+			if count != 1 {
+				t.Errorf("board.NewVariant(%s) failed: %v is in %d cages", dims, pos, count)
+			}
+		}
+	}
+}
+
+func TestValidateCages(t *testing.T) {
+	tests := []struct {
+		name     string
+		cages    board.Cages
+		expected error
+	}{
+		{"valid", board.Cages{{Sum: 3, Cells: []board.Point2{{X: 0, Y: 0}, {X: 1, Y: 0}}}}, nil},
+		{"out of board", board.Cages{{Sum: 3, Cells: []board.Point2{{X: 0, Y: 0}, {X: 9, Y: 0}}}}, board.ErrInvalidCages},
+		{"overlap", board.Cages{
+			{Sum: 3, Cells: []board.Point2{{X: 0, Y: 0}, {X: 1, Y: 0}}},
+			{Sum: 3, Cells: []board.Point2{{X: 1, Y: 0}, {X: 2, Y: 0}}},
+		}, board.ErrInvalidCages},
+		{"sum too small", board.Cages{{Sum: 2, Cells: []board.Point2{{X: 0, Y: 0}, {X: 1, Y: 0}}}}, board.ErrInvalidCages},
+		{"sum too large", board.Cages{{Sum: 18, Cells: []board.Point2{{X: 0, Y: 0}, {X: 1, Y: 0}}}}, board.ErrInvalidCages},
+		{"empty", board.Cages{{Sum: 0}}, board.ErrInvalidCages},
+	}
+
+	for _, test := range tests {
+		if actual := board.ValidateCages(test.cages, 9); actual != test.expected {
+			t.Errorf("ValidateCages(%s) failed: Expected: %v, Actual:%v", test.name, test.expected, actual)
+		}
+	}
+}
+
+func TestKillerConflicts(t *testing.T) {
+	tests := []struct {
+		name     string
+		values   map[board.Point2]int
+		pos      board.Point2
+		value    int
+		expected map[board.Point2]struct{}
+	}{
+		{
+			name:     "no conflict",
+			values:   map[board.Point2]int{{X: 0, Y: 0}: 6},
+			pos:      board.Point2{X: 1, Y: 0},
+			value:    2,
+			expected: map[board.Point2]struct{}{},
+		},
+		{
+			name:     "sum exceeded",
+			values:   map[board.Point2]int{{X: 0, Y: 0}: 6},
+			pos:      board.Point2{X: 1, Y: 0},
+			value:    7,
+			expected: map[board.Point2]struct{}{{X: 0, Y: 0}: {}},
+		},
+		{
+			name:     "sum not reached",
+			values:   map[board.Point2]int{{X: 0, Y: 1}: 7},
+			pos:      board.Point2{X: 1, Y: 1},
+			value:    4,
+			expected: map[board.Point2]struct{}{{X: 0, Y: 1}: {}},
+		},
+		{
+			name:     "repeated in cage",
+			values:   map[board.Point2]int{{X: 0, Y: 1}: 6},
+			pos:      board.Point2{X: 1, Y: 1},
+			value:    6,
+			expected: map[board.Point2]struct{}{{X: 0, Y: 1}: {}},
+		},
+	}
+
+	for _, test := range tests {
+		tBoard := getKillerBoard()
+		for pos, value := range test.values {
+			tBoard.Set(pos, value)
+		}
+
+		if actual := tBoard.GetConflicts(test.pos, test.value); !reflect.DeepEqual(actual, test.expected) {
+			t.Errorf("GetConflicts(%s) failed: Expected: %v, Actual:%v", test.name, test.expected, actual)
+		}
+	}
+}
+
+func TestKillerPeers(t *testing.T) {
+	tBoard := getKillerBoard()
+
+	peers := tBoard.GetPeers(board.Point2{X: 0, Y: 1})
+	if _, exist := peers[board.Point2{X: 1, Y: 1}]; !exist || len(peers) != 20 {
+		t.Errorf("GetPeers() failed: Expected: 20 peers with the cage, Actual:%v", peers)
+	}
+
+	cage, exist := tBoard.GetCage(board.Point2{X: 2, Y: 0})
+	if !exist || cage.Sum != 12 {
+		t.Errorf("GetCage() failed: Expected: 12, Actual:%v", cage)
+	}
+	if _, exist := tBoard.GetCage(board.Point2{X: 5, Y: 5}); exist {
+		t.Errorf("GetCage() failed: the cell is not in a cage")
+	}
+}
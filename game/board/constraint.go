@@ -0,0 +1,438 @@
+package board
+
+import (
+	"errors"
+	"math/rand"
+	"strings"
+)
+
+// ErrUnknownRule is returned when a rule name can not be parsed
+var ErrUnknownRule = errors.New("unknown rule")
+
+// ErrUnsupportedRules is returned when no grid of the
+// board size can be generated with the given rules
+var ErrUnsupportedRules = errors.New("rules are not supported on this board size")
+
+// ErrGridNotFound is returned when the search found no grid of the board
+// size with the given rules, they may still be possible on the size
+var ErrGridNotFound = errors.New("no grid is found with the rules on this board size")
+
+// Rule is a variant rule on top of the rows, columns and boxes,
+// the rules are bit flags so they can be combined with |
+type Rule uint16
+
+const (
+	// KillerRule splits the board into cages with sums
+	KillerRule = Rule(1 << iota)
+	// DiagonalRule keeps the values from repeating in the two diagonals
+	DiagonalRule
+	// WindokuRule keeps the values from repeating
+	// in the windows between the boxes
+	WindokuRule
+	// AntiKnightRule keeps the cells a knight's move apart
+	// from having the same value
+	AntiKnightRule
+	// AntiKingRule keeps the cells a king's move apart
+	// from having the same value
+	AntiKingRule
+	// NonConsecutiveRule keeps the neighbour cells
+	// from having consecutive values
+	NonConsecutiveRule
+	// EvenOddRule marks some of the cells as even or odd
+	EvenOddRule
+	// JigsawRule replaces the boxes with irregular regions
+	JigsawRule
+)
+
+// Rules are all rules in menu order
+var Rules = []Rule{KillerRule, JigsawRule, DiagonalRule, WindokuRule,
+	AntiKnightRule, AntiKingRule, NonConsecutiveRule, EvenOddRule}
+
+var ruleNames = map[Rule]string{
+	KillerRule:         "Killer",
+	DiagonalRule:       "Diagonal",
+	WindokuRule:        "Windoku",
+	AntiKnightRule:     "Anti-Knight",
+	AntiKingRule:       "Anti-King",
+	NonConsecutiveRule: "Non-Consecutive",
+	EvenOddRule:        "Even/Odd",
+	JigsawRule:         "Jigsaw",
+}
+
+// ruleCodes are the letters of the rules in puzzle ids and saves
+var ruleCodes = map[Rule]string{
+	KillerRule:         "K",
+	DiagonalRule:       "X",
+	WindokuRule:        "W",
+	AntiKnightRule:     "N",
+	AntiKingRule:       "A",
+	NonConsecutiveRule: "C",
+	EvenOddRule:        "E",
+	JigsawRule:         "J",
+}
+
+// ParseRules returns the rules of the given comma separated names
+func ParseRules(names string) (Rule, error) {
+	rules := Rule(0)
+	for _, name := range strings.Split(names, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" || strings.EqualFold(name, "none") {
+			continue
+		}
+
+		rule, exist := findRule(func(rule Rule) bool { return strings.EqualFold(name, ruleNames[rule]) })
+		if !exist {
+			return 0, ErrUnknownRule
+		}
+		rules |= rule
+	}
+	return rules, nil
+}
+
+// ParseRuleCodes returns the rules of the given rule letters, see Rule.Code
+func ParseRuleCodes(codes string) (Rule, error) {
+	rules := Rule(0)
+	for _, char := range codes {
+		rule, exist := findRule(func(rule Rule) bool { return strings.EqualFold(string(char), ruleCodes[rule]) })
+		if !exist {
+			return 0, ErrUnknownRule
+		}
+		rules |= rule
+	}
+	return rules, nil
+}
+
+func findRule(match func(rule Rule) bool) (Rule, bool) {
+	for _, rule := range Rules {
+		if match(rule) {
+			return rule, true
+		}
+	}
+	return 0, false
+}
+
+// Has returns if all of the given rules are set
+func (rules Rule) Has(rule Rule) bool {
+	return rules&rule == rule
+}
+
+// String returns the names of the rules, "None" if there are no rules
+func (rules Rule) String() string {
+	names := []string{}
+	for _, rule := range Rules {
+		if rules.Has(rule) {
+			names = append(names, ruleNames[rule])
+		}
+	}
+
+	if len(names) == 0 {
+		return "None"
+	}
+	return strings.Join(names, ", ")
+}
+
+// Code returns the letters of the rules in menu order, such as "KX"
+func (rules Rule) Code() string {
+	code := ""
+	for _, rule := range Rules {
+		if rules.Has(rule) {
+			code += ruleCodes[rule]
+		}
+	}
+	return code
+}
+
+// Constraint is a rule of a board on top of the rows, columns and boxes,
+// the generator, the solver and the conflicts of the board
+// follow the constraints of the board
+type Constraint interface {
+	// Rule returns the rule of the constraint
+	Rule() Rule
+
+	// Peers returns the positions of the cells that can not have the
+	// same value as the cell at the given position on a board of the given size
+	Peers(pos Point2, size int) []Point2
+
+	// Conflicts returns the positions of the cells of the grid that break
+	// the constraint together with the given value at the given position,
+	// the position itself if the value breaks the constraint alone.
+	// The value of the given position in the grid is ignored.
+	Conflicts(grid Grid, pos Point2, value int) []Point2
+}
+
+// NewConstraint returns the constraint of the given rule. The killer, jigsaw
+// and even/odd rules depend on the board, see Cages, Regions and EvenOdd.
+func NewConstraint(rule Rule) (Constraint, bool) {
+	switch rule {
+	case DiagonalRule:
+		return Diagonals{}, true
+	case WindokuRule:
+		return Windoku{}, true
+	case AntiKnightRule:
+		return AntiKnight{}, true
+	case AntiKingRule:
+		return AntiKing{}, true
+	case NonConsecutiveRule:
+		return NonConsecutive{}, true
+	}
+	return nil, false
+}
+
+// getPeerConflicts returns the given peers that have the given value
+func getPeerConflicts(grid Grid, peers []Point2, value int) []Point2 {
+	conflicts := []Point2{}
+	for _, peer := range peers {
+		if grid[peer.Y][peer.X] == value {
+			conflicts = append(conflicts, peer)
+		}
+	}
+	return conflicts
+}
+
+// getOffsetPeers returns the cells at the given offsets from the position
+func getOffsetPeers(pos Point2, size int, offsets []Point2) []Point2 {
+	peers := []Point2{}
+	for _, offset := range offsets {
+		cPos := Point2{pos.X + offset.X, pos.Y + offset.Y}
+		if cPos.X >= 0 && cPos.Y >= 0 && cPos.X < size && cPos.Y < size {
+			peers = append(peers, cPos)
+		}
+	}
+	return peers
+}
+
+// Diagonals is the constraint of Sudoku X,
+// the values can not repeat in the two diagonals
+type Diagonals struct{}
+
+func (Diagonals) Rule() Rule {
+	return DiagonalRule
+}
+
+func (Diagonals) Peers(pos Point2, size int) []Point2 {
+	peers := []Point2{}
+	for i := 0; i < size; i++ {
+		if pos.X == pos.Y && i != pos.X {
+			peers = append(peers, Point2{i, i})
+		}
+		if pos.X+pos.Y == size-1 && i != pos.X && !containsPos(peers, Point2{i, size - 1 - i}) {
+			peers = append(peers, Point2{i, size - 1 - i})
+		}
+	}
+	return peers
+}
+
+func (d Diagonals) Conflicts(grid Grid, pos Point2, value int) []Point2 {
+	return getPeerConflicts(grid, d.Peers(pos, grid.Size()), value)
+}
+
+// Windoku is the constraint of the extra boxes, the windows. The windows
+// have the size of the boxes and are one cell apart from each other and the
+// edges of the board, the values can not repeat in a window.
+type Windoku struct{}
+
+func (Windoku) Rule() Rule {
+	return WindokuRule
+}
+
+// Windows returns the positions of the cells
+// of the windows of the given dimensions
+func (Windoku) Windows(dims Dimensions) [][]Point2 {
+	windows := [][]Point2{}
+	size := dims.Size()
+	for y := 1; y+dims.BoxHeight < size; y += dims.BoxHeight + 1 {
+		for x := 1; x+dims.BoxWidth < size; x += dims.BoxWidth + 1 {
+			window := []Point2{}
+			for i := 0; i < dims.BoxHeight; i++ {
+				for j := 0; j < dims.BoxWidth; j++ {
+					window = append(window, Point2{x + j, y + i})
+				}
+			}
+			windows = append(windows, window)
+		}
+	}
+	return windows
+}
+
+func (w Windoku) Peers(pos Point2, size int) []Point2 {
+	dims, err := DimensionsOf(size)
+	if err != nil {
+		return nil
+	}
+
+	for _, window := range w.Windows(dims) {
+		if containsPos(window, pos) {
+			peers := []Point2{}
+			for _, cPos := range window {
+				if cPos != pos {
+					peers = append(peers, cPos)
+				}
+			}
+			return peers
+		}
+	}
+	return nil
+}
+
+func (w Windoku) Conflicts(grid Grid, pos Point2, value int) []Point2 {
+	return getPeerConflicts(grid, w.Peers(pos, grid.Size()), value)
+}
+
+// AntiKnight is the constraint of the cells
+// a knight's move apart, they can not have the same value
+type AntiKnight struct{}
+
+var knightOffsets = []Point2{{1, 2}, {2, 1}, {2, -1}, {1, -2}, {-1, -2}, {-2, -1}, {-2, 1}, {-1, 2}}
+
+func (AntiKnight) Rule() Rule {
+	return AntiKnightRule
+}
+
+func (AntiKnight) Peers(pos Point2, size int) []Point2 {
+	return getOffsetPeers(pos, size, knightOffsets)
+}
+
+func (a AntiKnight) Conflicts(grid Grid, pos Point2, value int) []Point2 {
+	return getPeerConflicts(grid, a.Peers(pos, grid.Size()), value)
+}
+
+// AntiKing is the constraint of the cells a king's move apart,
+// they can not have the same value. The cells next to each
+// other are already in the same row or column.
+type AntiKing struct{}
+
+var kingOffsets = []Point2{{1, 1}, {1, -1}, {-1, -1}, {-1, 1}}
+
+func (AntiKing) Rule() Rule {
+	return AntiKingRule
+}
+
+func (AntiKing) Peers(pos Point2, size int) []Point2 {
+	return getOffsetPeers(pos, size, kingOffsets)
+}
+
+func (a AntiKing) Conflicts(grid Grid, pos Point2, value int) []Point2 {
+	return getPeerConflicts(grid, a.Peers(pos, grid.Size()), value)
+}
+
+// NonConsecutive is the constraint of the cells next to each other,
+// their values can not be consecutive
+type NonConsecutive struct{}
+
+func (NonConsecutive) Rule() Rule {
+	return NonConsecutiveRule
+}
+
+func (NonConsecutive) Peers(pos Point2, size int) []Point2 {
+	return nil
+}
+
+func (NonConsecutive) Conflicts(grid Grid, pos Point2, value int) []Point2 {
+	conflicts := []Point2{}
+	for _, neighbour := range getNeighbours(pos, grid.Size()) {
+		cValue := grid[neighbour.Y][neighbour.X]
+		if cValue != 0 && (cValue == value-1 || cValue == value+1) {
+			conflicts = append(conflicts, neighbour)
+		}
+	}
+	return conflicts
+}
+
+// EvenOdd is the constraint of the marked cells,
+// the even cells have even values and the odd cells have odd values
+type EvenOdd struct {
+	Even []Point2
+	Odd  []Point2
+}
+
+// evenOddShare is the share of the cells
+// the even/odd rule marks, one in every evenOddShare cells
+const evenOddShare = 4
+
+func (EvenOdd) Rule() Rule {
+	return EvenOddRule
+}
+
+func (EvenOdd) Peers(pos Point2, size int) []Point2 {
+	return nil
+}
+
+func (evenOdd EvenOdd) Conflicts(grid Grid, pos Point2, value int) []Point2 {
+	if (value%2 == 1 && containsPos(evenOdd.Even, pos)) || (value%2 == 0 && containsPos(evenOdd.Odd, pos)) {
+		return []Point2{pos}
+	}
+	return []Point2{}
+}
+
+// generateEvenOdd marks random cells of the complete grid as even or odd
+func generateEvenOdd(complete Grid, random *rand.Rand) EvenOdd {
+	evenOdd := EvenOdd{}
+	positions := randomPositions(complete.Size(), random)
+	for _, pos := range positions[:len(positions)/evenOddShare] {
+		if complete[pos.Y][pos.X]%2 == 0 {
+			evenOdd.Even = append(evenOdd.Even, pos)
+		} else {
+			evenOdd.Odd = append(evenOdd.Odd, pos)
+		}
+	}
+	return evenOdd
+}
+
+// gridRules are the rules that constrain the complete grids,
+// the other rules are made from the complete grid
+const gridRules = DiagonalRule | WindokuRule | AntiKnightRule | AntiKingRule | NonConsecutiveRule
+
+// shuffleVariantGrid returns the given complete grid flipped, transposed
+// and with swapped values where the constraints allow it
+func shuffleVariantGrid(base Grid, dims Dimensions, rules Rule, constraints []Constraint, random *rand.Rand) Grid {
+	size := dims.Size()
+	transpose := dims.BoxWidth == dims.BoxHeight && random.Intn(2) == 0
+	flipX, flipY := random.Intn(2) == 0, random.Intn(2) == 0
+
+	values := generateFirstRow(size, random)
+	if rules.Has(NonConsecutiveRule) {
+		// reversing the values is the only swap that keeps them non consecutive
+		reverse := random.Intn(2) == 0
+		for i := range values {
+			values[i] = i + 1
+			if reverse {
+				values[i] = size - i
+			}
+		}
+	}
+
+	grid := NewGrid(size)
+	for i := 0; i < size; i++ {
+		for j := 0; j < size; j++ {
+			x, y := j, i
+			if transpose {
+				x, y = y, x
+			}
+			if flipX {
+				x = size - 1 - x
+			}
+			if flipY {
+				y = size - 1 - y
+			}
+			grid[i][j] = values[base[y][x]-1]
+		}
+	}
+
+	// the windows of some sizes are not symmetric
+	if _, err := newSolver(grid, constraints); err != nil {
+		return base.Copy()
+	}
+	return grid
+}
+
+// getConstraints returns the constraints of the given rules
+// that do not depend on the solution of the board
+func getConstraints(rules Rule) []Constraint {
+	constraints := []Constraint{}
+	for _, rule := range Rules {
+		if constraint, exist := NewConstraint(rule); exist && rules.Has(rule) {
+			constraints = append(constraints, constraint)
+		}
+	}
+	return constraints
+}
@@ -0,0 +1,146 @@
+package board_test
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/serhatsdev/sudoku/game/board"
+)
+
+func TestParseRules(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected board.Rule
+		err      error
+	}{
+		{"none", 0, nil},
+		{"", 0, nil},
+		{"killer", board.KillerRule, nil},
+		{"Diagonal, anti-knight", board.DiagonalRule | board.AntiKnightRule, nil},
+		{"even/odd,non-consecutive", board.EvenOddRule | board.NonConsecutiveRule, nil},
+		{"jigsaw", board.JigsawRule, nil},
+		{"sandwich", 0, board.ErrUnknownRule},
+	}
+
+	for _, test := range tests {
+		actual, err := board.ParseRules(test.text)
+		if actual != test.expected || err != test.err {
+			t.Errorf("ParseRules(%q) failed: Expected: %v %v, Actual:%v %v",
+				test.text, test.expected, test.err, actual, err)
+		}
+	}
+}
+
+func TestRuleNames(t *testing.T) {
+	tests := []struct {
+		rules        board.Rule
+		expectedName string
+		expectedCode string
+	}{
+		{0, "None", ""},
+		{board.WindokuRule, "Windoku", "W"},
+		{board.AntiKingRule | board.KillerRule, "Killer, Anti-King", "KA"},
+	}
+
+	for _, test := range tests {
+		if actual := test.rules.String(); actual != test.expectedName {
+			t.Errorf("String() failed: Expected: %v, Actual:%v", test.expectedName, actual)
+		}
+		if actual := test.rules.Code(); actual != test.expectedCode {
+			t.Errorf("Code() failed: Expected: %v, Actual:%v", test.expectedCode, actual)
+		}
+		if actual, err := board.ParseRuleCodes(test.rules.Code()); actual != test.rules || err != nil {
+			t.Errorf("ParseRuleCodes(%q) failed: Expected: %v, Actual:%v %v", test.rules.Code(), test.rules, actual, err)
+		}
+	}
+}
+
+func TestConstraintConflicts(t *testing.T) {
+	tests := []struct {
+		name       string
+		constraint board.Constraint
+		values     map[board.Point2]int
+		pos        board.Point2
+		value      int
+		expected   []board.Point2
+	}{
+		{"diagonal", board.Diagonals{}, map[board.Point2]int{{X: 0, Y: 0}: 5}, board.Point2{X: 8, Y: 8}, 5, []board.Point2{{X: 0, Y: 0}}},
+		{"off diagonal", board.Diagonals{}, map[board.Point2]int{{X: 0, Y: 0}: 5}, board.Point2{X: 7, Y: 8}, 5, nil},
+		{"window", board.Windoku{}, map[board.Point2]int{{X: 1, Y: 1}: 3}, board.Point2{X: 3, Y: 3}, 3, []board.Point2{{X: 1, Y: 1}}},
+		{"off window", board.Windoku{}, map[board.Point2]int{{X: 1, Y: 1}: 3}, board.Point2{X: 4, Y: 4}, 3, nil},
+		{"knight move", board.AntiKnight{}, map[board.Point2]int{{X: 0, Y: 0}: 4}, board.Point2{X: 1, Y: 2}, 4, []board.Point2{{X: 0, Y: 0}}},
+		{"not a knight move", board.AntiKnight{}, map[board.Point2]int{{X: 0, Y: 0}: 4}, board.Point2{X: 1, Y: 1}, 4, nil},
+		{"king move", board.AntiKing{}, map[board.Point2]int{{X: 0, Y: 0}: 4}, board.Point2{X: 1, Y: 1}, 4, []board.Point2{{X: 0, Y: 0}}},
+		{"consecutive", board.NonConsecutive{}, map[board.Point2]int{{X: 0, Y: 0}: 4}, board.Point2{X: 1, Y: 0}, 5, []board.Point2{{X: 0, Y: 0}}},
+		{"not consecutive", board.NonConsecutive{}, map[board.Point2]int{{X: 0, Y: 0}: 4}, board.Point2{X: 1, Y: 0}, 6, nil},
+		{"odd on even", board.EvenOdd{Even: []board.Point2{{X: 0, Y: 0}}}, nil, board.Point2{X: 0, Y: 0}, 3, []board.Point2{{X: 0, Y: 0}}},
+		{"even on even", board.EvenOdd{Even: []board.Point2{{X: 0, Y: 0}}}, nil, board.Point2{X: 0, Y: 0}, 2, nil},
+	}
+
+	for _, test := range tests {
+		grid := board.NewGrid(9)
+		for pos, value := range test.values {
+			grid[pos.Y][pos.X] = value
+		}
+
+		actual := test.constraint.Conflicts(grid, test.pos, test.value)
+		if len(actual) != len(test.expected) || (len(actual) > 0 && !reflect.DeepEqual(actual, test.expected)) {
+			t.Errorf("Conflicts(%s) failed: Expected: %v, Actual:%v", test.name, test.expected, actual)
+		}
+	}
+}
+
+func TestNewVariant(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	for _, rule := range board.Rules {
+		tBoard, err := board.NewVariant(board.Classic, board.Medium, board.Rotational, rule, random)
+		if err != nil {
+			t.Fatalf("board.NewVariant(%s) failed: %v", rule, err)
+		}
+		if tBoard.Rules() != rule {
+			t.Errorf("board.NewVariant(%s) failed: Expected: %v, Actual:%v", rule, rule, tBoard.Rules())
+		}
+
+		solution := board.NewGrid(9)
+		for i := range solution {
+			for j := range solution[i] {
+				solution[i][j] = tBoard.GetCorrect(board.Point2{X: j, Y: i})
+			}
+		}
+		if err := board.ValidateConstraints(solution, tBoard.Constraints()...); err != nil {
+			t.Errorf("board.NewVariant(%s) failed: the solution breaks the rules", rule)
+		}
+	}
+}
+
+func TestUnsupportedRules(t *testing.T) {
+	tests := []struct {
+		dims     board.Dimensions
+		rules    board.Rule
+		expected error
+	}{
+		{board.Classic, board.DiagonalRule | board.WindokuRule, nil},
+		{board.Classic, board.KillerRule | board.EvenOddRule, nil},
+		{board.Classic, board.WindokuRule | board.AntiKnightRule | board.NonConsecutiveRule, board.ErrUnsupportedRules},
+		{board.Dimensions{BoxWidth: 2, BoxHeight: 2}, board.AntiKingRule, board.ErrUnsupportedRules},
+		{board.Dimensions{BoxWidth: 2, BoxHeight: 2}, board.NonConsecutiveRule, board.ErrUnsupportedRules},
+		{board.Dimensions{BoxWidth: 4, BoxHeight: 4}, board.WindokuRule, nil},
+		{board.Dimensions{BoxWidth: 4, BoxHeight: 4}, board.DiagonalRule | board.WindokuRule, board.ErrGridNotFound},
+	}
+
+	for _, test := range tests {
+		if actual := board.ValidateRules(test.dims, test.rules); actual != test.expected {
+			t.Errorf("ValidateRules(%s, %s) failed: Expected: %v, Actual:%v", test.dims, test.rules, test.expected, actual)
+		}
+		if actual := board.SupportsRules(test.dims, test.rules); actual != (test.expected == nil) {
+			t.Errorf("SupportsRules(%s, %s) failed: Expected: %v, Actual:%v", test.dims, test.rules, test.expected == nil, actual)
+		}
+	}
+
+	random := rand.New(rand.NewSource(1))
+	_, err := board.NewVariant(board.Dimensions{BoxWidth: 2, BoxHeight: 2}, board.Easy, board.NoSymmetry, board.AntiKingRule, random)
+	if err != board.ErrUnsupportedRules {
+		t.Errorf("board.NewVariant() failed: Expected: %v, Actual:%v", board.ErrUnsupportedRules, err)
+	}
+}
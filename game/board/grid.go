@@ -1,72 +1,174 @@
 package board
 
 import (
+	"errors"
+	"fmt"
 	"math/rand"
-	"time"
 )
 
-const BlockSize = 3
-const Size = BlockSize * BlockSize
+// ErrInvalidSize is returned when a board size is not supported
+var ErrInvalidSize = errors.New("board size is not supported")
 
-type Row [Size]int
+// Dimensions are the sizes of the boxes of a board, a board has
+// BoxWidth*BoxHeight rows, columns, boxes and values
+type Dimensions struct {
+	BoxWidth, BoxHeight int
+}
 
-type Column [Size]int
+// Classic is the dimensions of the 9x9 board
+var Classic = Dimensions{3, 3}
 
-type Block [BlockSize][BlockSize]int
+// Sizes are the supported board dimensions from the smallest to the largest,
+// the boxes of the rectangular boards are wider than they are tall
+var Sizes = []Dimensions{{2, 2}, {3, 2}, Classic, {4, 3}, {4, 4}}
 
-type Grid [Size][Size]int
+// DimensionsOf returns the dimensions of the board
+// with the given number of rows and columns
+func DimensionsOf(size int) (Dimensions, error) {
+	for _, dims := range Sizes {
+		if dims.Size() == size {
+			return dims, nil
+		}
+	}
+	return Dimensions{}, ErrInvalidSize
+}
 
-func (grid Grid) GetRow(index int) Row {
-	row := Row{}
-	for i := 0; i < Size; i++ {
-		row[i] = grid[index][i]
+// Size returns the number of rows, columns, boxes and values
+func (dims Dimensions) Size() int {
+	return dims.BoxWidth * dims.BoxHeight
+}
+
+// String returns the size of the board, such as "9x9"
+func (dims Dimensions) String() string {
+	return fmt.Sprintf("%dx%d", dims.Size(), dims.Size())
+}
+
+// BoxIndex returns the index of the box the given position is in,
+// the boxes are numbered row by row
+func (dims Dimensions) BoxIndex(pos Point2) int {
+	return pos.Y/dims.BoxHeight*dims.BoxHeight + pos.X/dims.BoxWidth
+}
+
+// boxOrigin returns the top left position of the box with the given index
+func (dims Dimensions) boxOrigin(index int) Point2 {
+	return Point2{index % dims.BoxHeight * dims.BoxWidth, index / dims.BoxHeight * dims.BoxHeight}
+}
+
+// symbols are the characters of the values, the boards
+// larger than 9x9 continue with letters after 9
+const symbols = "123456789ABCDEFG"
+
+// Symbol returns the character of the given value
+func Symbol(value int) rune {
+	if value < 1 || value > len(symbols) {
+		return ' '
 	}
-	return row
+	return rune(symbols[value-1])
 }
 
-func (grid Grid) GetColumn(index int) Column {
-	column := Column{}
-	for i := 0; i < Size; i++ {
-		column[i] = grid[i][index]
+// SymbolValue returns the value of the given character,
+// letters are case insensitive. It returns 0 if the
+// character is not a symbol.
+func SymbolValue(char rune) int {
+	if char >= 'a' && char <= 'z' {
+		char -= 'a' - 'A'
 	}
-	return column
+
+	for i, symbol := range symbols {
+		if symbol == char {
+			return i + 1
+		}
+	}
+	return 0
 }
 
-func (grid Grid) GetBlock(rowIndex, columnIndex int) Block {
-	block := Block{}
-	gridRow, gridColumn := blockToGridIndex(rowIndex, columnIndex)
+type Row []int
+
+type Column []int
+
+type Block [][]int
+
+// Grid is the values of a board row by row, 0 is an empty cell
+type Grid [][]int
+
+// NewGrid returns an empty grid with the given number of rows and columns
+func NewGrid(size int) Grid {
+	grid := make(Grid, size)
+	for i := range grid {
+		grid[i] = make([]int, size)
+	}
+	return grid
+}
 
-	for i := 0; i < BlockSize; i++ {
-		for j := 0; j < BlockSize; j++ {
-			block[i][j] = grid[gridRow+i][gridColumn+j]
+// Size returns the number of rows and columns of the grid
+func (grid Grid) Size() int {
+	return len(grid)
+}
+
+// Copy returns a grid with the same values
+func (grid Grid) Copy() Grid {
+	copied := make(Grid, len(grid))
+	for i := range grid {
+		copied[i] = append([]int{}, grid[i]...)
+	}
+	return copied
+}
+
+// Dimensions returns the dimensions of the grid
+func (grid Grid) Dimensions() (Dimensions, error) {
+	dims, err := DimensionsOf(len(grid))
+	if err != nil {
+		return Dimensions{}, err
+	}
+
+	for _, row := range grid {
+		if len(row) != len(grid) {
+			return Dimensions{}, ErrInvalidSize
 		}
 	}
+	return dims, nil
+}
 
-	return block
+func (grid Grid) GetRow(index int) Row {
+	return append(Row{}, grid[index]...)
 }
 
-func blockToGridIndex(blockRowIndex, blockColumnIndex int) (int, int) {
-	return blockRowIndex * 3, blockColumnIndex * 3
+func (grid Grid) GetColumn(index int) Column {
+	column := Column{}
+	for i := 0; i < grid.Size(); i++ {
+		column = append(column, grid[i][index])
+	}
+	return column
 }
 
-func gridToBlockIndex(gridRowIndex, gridColumnIndex int) (int, int) {
-	return int(gridRowIndex / 3), int(gridColumnIndex / 3)
+// GetBlock returns the box at the given box row and column
+func (grid Grid) GetBlock(rowIndex, columnIndex int) Block {
+	dims, _ := DimensionsOf(grid.Size())
+	origin := dims.boxOrigin(rowIndex*dims.BoxHeight + columnIndex)
+
+	block := Block{}
+	for i := 0; i < dims.BoxHeight; i++ {
+		block = append(block, append([]int{}, grid[origin.Y+i][origin.X:origin.X+dims.BoxWidth]...))
+	}
+	return block
 }
 
-func generateFirstRow() Row {
-	row := Row{1, 2, 3, 4, 5, 6, 7, 8, 9}
+func generateFirstRow(size int, random *rand.Rand) Row {
+	row := Row{}
+	for i := 1; i <= size; i++ {
+		row = append(row, i)
+	}
 
-	rand.Seed(time.Now().UnixNano())
-	rand.Shuffle(9, func(i, j int) {
+	random.Shuffle(size, func(i, j int) {
 		row[i], row[j] = row[j], row[i]
 	})
 
 	return row
 }
 
-func findNextEmptyCell(grid *Grid) (int, int, bool) {
-	for i := 0; i < Size; i++ {
-		for j := 0; j < Size; j++ {
+func findNextEmptyCell(grid Grid) (int, int, bool) {
+	for i := 0; i < grid.Size(); i++ {
+		for j := 0; j < grid.Size(); j++ {
 			if grid[i][j] == 0 {
 				return i, j, true
 			}
@@ -75,28 +177,17 @@ func findNextEmptyCell(grid *Grid) (int, int, bool) {
 	return 0, 0, false
 }
 
-func isRowValidForInsert(row Row, index, value int) bool {
-	for i := 0; i < Size; i++ {
-		if row[i] == value && index != i {
+func isGridValidForInsert(grid Grid, dims Dimensions, rowIndex, columnIndex, value int) bool {
+	for i := 0; i < grid.Size(); i++ {
+		if grid[rowIndex][i] == value || grid[i][columnIndex] == value {
 			return false
 		}
 	}
-	return true
-}
-
-func isColumnValidForInsert(column Column, index, value int) bool {
-	for i := 0; i < Size; i++ {
-		if column[i] == value && index != i {
-			return false
-		}
-	}
-	return true
-}
 
-func isBlockValidForInsert(block Block, rowIndex, columnIndex, value int) bool {
-	for i := 0; i < BlockSize; i++ {
-		for j := 0; j < BlockSize; j++ {
-			if block[i][j] == value && rowIndex != i && columnIndex != j {
+	origin := dims.boxOrigin(dims.BoxIndex(Point2{columnIndex, rowIndex}))
+	for i := 0; i < dims.BoxHeight; i++ {
+		for j := 0; j < dims.BoxWidth; j++ {
+			if grid[origin.Y+i][origin.X+j] == value {
 				return false
 			}
 		}
@@ -104,41 +195,81 @@ func isBlockValidForInsert(block Block, rowIndex, columnIndex, value int) bool {
 	return true
 }
 
-func isGridValidForInsert(grid *Grid, rowIndex, columnIndex, value int) bool {
-	blockRow := rowIndex % Size
-	blockColumn := columnIndex % Size
-
-	return isRowValidForInsert(grid.GetRow(rowIndex), columnIndex, value) &&
-		isColumnValidForInsert(grid.GetColumn(columnIndex), rowIndex, value) &&
-		isBlockValidForInsert(
-			grid.GetBlock(gridToBlockIndex(rowIndex, columnIndex)),
-			blockRow,
-			blockColumn,
-			value,
-		)
-}
-
-func completeGrid(grid *Grid) *Grid {
+func completeGrid(grid Grid, dims Dimensions) bool {
 	rowIndex, columnIndex, exist := findNextEmptyCell(grid)
 	if !exist {
-		return grid
+		return true
 	}
 
-	for i := 1; i <= Size; i++ {
-		if isGridValidForInsert(grid, rowIndex, columnIndex, i) {
+	for i := 1; i <= grid.Size(); i++ {
+		if isGridValidForInsert(grid, dims, rowIndex, columnIndex, i) {
 			grid[rowIndex][columnIndex] = i
 
-			if solved := completeGrid(grid); solved != nil {
-				return solved
+			if completeGrid(grid, dims) {
+				return true
 			}
 		}
 		grid[rowIndex][columnIndex] = 0
 	}
 
-	return nil
+	return false
+}
+
+// GenerateGrid returns a complete grid with the given dimensions,
+// the given random source decides which grid is generated
+func GenerateGrid(dims Dimensions, random *rand.Rand) Grid {
+	// backtracking takes minutes on some of the larger grids, the 9x9
+	// grids keep using it so the puzzle ids generate the same puzzles
+	if dims != Classic {
+		return shuffleGrid(getPatternGrid(dims), dims, random)
+	}
+
+	grid := NewGrid(dims.Size())
+	grid[0] = generateFirstRow(dims.Size(), random)
+	completeGrid(grid, dims)
+	return grid
 }
 
-func GenerateGrid() Grid {
-	grid := Grid{generateFirstRow()}
-	return *completeGrid(&grid)
+// getPatternGrid returns a complete grid where every row
+// is the row above shifted by the width of the boxes
+func getPatternGrid(dims Dimensions) Grid {
+	size := dims.Size()
+	grid := NewGrid(size)
+	for i := 0; i < size; i++ {
+		for j := 0; j < size; j++ {
+			grid[i][j] = (dims.BoxWidth*(i%dims.BoxHeight)+i/dims.BoxHeight+j)%size + 1
+		}
+	}
+	return grid
+}
+
+// shuffleGrid returns a complete grid made by swapping the values, the rows
+// within the bands of boxes, the bands, the columns within the stacks
+// of boxes and the stacks of the given complete grid
+func shuffleGrid(grid Grid, dims Dimensions, random *rand.Rand) Grid {
+	size := dims.Size()
+	values := generateFirstRow(size, random)
+	rows := getShuffledLines(dims.BoxHeight, dims.BoxWidth, random)
+	columns := getShuffledLines(dims.BoxWidth, dims.BoxHeight, random)
+
+	shuffled := NewGrid(size)
+	for i := 0; i < size; i++ {
+		for j := 0; j < size; j++ {
+			shuffled[i][j] = values[grid[rows[i]][columns[j]]-1]
+		}
+	}
+	return shuffled
+}
+
+// getShuffledLines returns the indexes of count groups of lines
+// with the given number of lines each, the groups and the lines
+// within the groups are in random order
+func getShuffledLines(lines, count int, random *rand.Rand) []int {
+	indexes := []int{}
+	for _, group := range random.Perm(count) {
+		for _, line := range random.Perm(lines) {
+			indexes = append(indexes, group*lines+line)
+		}
+	}
+	return indexes
 }
@@ -1,6 +1,8 @@
 package board_test
 
 import (
+	"math/rand"
+	"reflect"
 	"testing"
 
 	"github.com/serhatsdev/sudoku/game/board"
@@ -24,7 +26,7 @@ func TestGridGetRow(t *testing.T) {
 		7, 5, 1, 8, 4, 6, 9, 3, 2,
 	}
 
-	if actual != expected {
+	if !reflect.DeepEqual(actual, expected) {
 		t.Errorf("GetRow doesn't return expected! Actual: %v", actual)
 	}
 }
@@ -47,7 +49,7 @@ func TestGridGetColumn(t *testing.T) {
 		2, 5, 3, 8, 7, 9, 6, 1, 4,
 	}
 
-	if actual != expected {
+	if !reflect.DeepEqual(actual, expected) {
 		t.Errorf("GetRow doesn't return expected!")
 	}
 }
@@ -72,18 +74,24 @@ func TestGridGetBlock(t *testing.T) {
 		{6, 1, 8},
 	}
 
-	if actual != expected {
+	if !reflect.DeepEqual(actual, expected) {
 		t.Errorf("GetBlock doesn't return expected! Actual: %v", actual)
 	}
 }
 
 func TestGenerateGrid(t *testing.T) {
-	grid := board.GenerateGrid()
+	random := rand.New(rand.NewSource(1))
+	for _, dims := range board.Sizes {
+		grid := board.GenerateGrid(dims, random)
+		if len(grid) != dims.Size() || board.CountSolutions(grid, 2) != 1 {
+			t.Errorf("GenerateGrid(%s) failed: Expected a valid grid, Actual:%v", dims, grid)
+		}
 
-	for i := 0; i < board.Size; i++ {
-		for j := 0; j < board.Size; j++ {
-			if grid[i][j] == 0 {
-				t.Errorf("grid is not generated completely")
+		for i := range grid {
+			for j := range grid[i] {
+				if grid[i][j] == 0 {
+					t.Errorf("GenerateGrid(%s) failed: grid is not generated completely", dims)
+				}
 			}
 		}
 	}
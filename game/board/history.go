@@ -0,0 +1,211 @@
+package board
+
+// CellState is the editable state of a cell
+type CellState struct {
+	Value int
+	Notes []int
+}
+
+// Change is a change of a single cell
+type Change struct {
+	Pos    Point2
+	Before CellState
+	After  CellState
+}
+
+// Move is a group of changes that are undone and redone together
+type Move []Change
+
+// History is a board that records the changes made to the wrapped board
+// so they can be undone and redone
+type History struct {
+	Board
+
+	moves []Move
+	// index is the number of moves that are applied
+	index int
+
+	groupDepth int
+	group      Move
+}
+
+// NewHistory returns a history with no moves for the given board
+func NewHistory(board Board) *History {
+	return &History{Board: board}
+}
+
+// NewHistoryWithMoves returns a history for the given board
+// with index of the moves already applied to the board
+func NewHistoryWithMoves(board Board, moves []Move, index int) *History {
+	if index < 0 || index > len(moves) {
+		index = len(moves)
+	}
+
+	return &History{Board: board, moves: moves, index: index}
+}
+
+// Set sets the cell value and records the change
+func (history *History) Set(pos Point2, value int) {
+	history.record(pos, func() { history.Board.Set(pos, value) })
+}
+
+// ToggleNote toggles the note and records the change
+func (history *History) ToggleNote(pos Point2, value int) {
+	history.record(pos, func() { history.Board.ToggleNote(pos, value) })
+}
+
+// ClearNotes clears the notes and records the change
+func (history *History) ClearNotes(pos Point2) {
+	history.record(pos, func() { history.Board.ClearNotes(pos) })
+}
+
+// BeginGroup starts a group, changes made until
+// the matching EndGroup call are recorded as a single move
+func (history *History) BeginGroup() {
+	history.groupDepth++
+}
+
+// ReopenGroup starts a group with the last applied move, changes made
+// until the matching EndGroup call are added to that move. The undone
+// moves are dropped. It starts a new group if there is no applied move.
+func (history *History) ReopenGroup() {
+	if history.groupDepth == 0 && history.index > 0 {
+		history.index--
+		history.group = history.moves[history.index]
+		history.moves = history.moves[:history.index]
+	}
+	history.groupDepth++
+}
+
+// EndGroup ends the current group
+func (history *History) EndGroup() {
+	if history.groupDepth == 0 {
+		return
+	}
+
+	history.groupDepth--
+	if history.groupDepth == 0 && len(history.group) > 0 {
+		history.push(history.group)
+		history.group = nil
+	}
+}
+
+// CanUndo returns if there is a move to undo
+func (history *History) CanUndo() bool {
+	return history.index > 0
+}
+
+// CanRedo returns if there is an undone move to redo
+func (history *History) CanRedo() bool {
+	return history.index < len(history.moves)
+}
+
+// Undo reverts the last applied move
+func (history *History) Undo() bool {
+	if !history.CanUndo() {
+		return false
+	}
+
+	history.index--
+	move := history.moves[history.index]
+	for i := len(move) - 1; i >= 0; i-- {
+		history.setState(move[i].Pos, move[i].Before)
+	}
+
+	return true
+}
+
+// Redo applies the last undone move again
+func (history *History) Redo() bool {
+	if !history.CanRedo() {
+		return false
+	}
+
+	for _, change := range history.moves[history.index] {
+		history.setState(change.Pos, change.After)
+	}
+	history.index++
+
+	return true
+}
+
+// LastMove returns the last applied move, nil if there is none
+func (history *History) LastMove() Move {
+	if history.index == 0 {
+		return nil
+	}
+	return history.moves[history.index-1]
+}
+
+// IsLastMove returns if the given move has the same changes
+// as the last applied move
+func (history *History) IsLastMove(move Move) bool {
+	last := history.LastMove()
+	if len(move) == 0 || len(move) != len(last) {
+		return false
+	}
+
+	for i := range move {
+		if move[i].Pos != last[i].Pos ||
+			!isSameState(move[i].Before, last[i].Before) ||
+			!isSameState(move[i].After, last[i].After) {
+			return false
+		}
+	}
+	return true
+}
+
+// Moves returns the recorded moves and the number of applied moves
+func (history *History) Moves() ([]Move, int) {
+	return history.moves, history.index
+}
+
+func (history *History) getState(pos Point2) CellState {
+	return CellState{history.Board.Get(pos), history.Board.GetNotes(pos)}
+}
+
+func (history *History) setState(pos Point2, state CellState) {
+	history.Board.Set(pos, state.Value)
+	history.Board.ClearNotes(pos)
+	for _, note := range state.Notes {
+		history.Board.ToggleNote(pos, note)
+	}
+}
+
+// record runs the edit and records the change if the cell is changed
+func (history *History) record(pos Point2, edit func()) {
+	before := history.getState(pos)
+	edit()
+	after := history.getState(pos)
+
+	if isSameState(before, after) {
+		return
+	}
+
+	change := Change{pos, before, after}
+	if history.groupDepth > 0 {
+		history.group = append(history.group, change)
+	} else {
+		history.push(Move{change})
+	}
+}
+
+// push adds the move and drops the undone moves
+func (history *History) push(move Move) {
+	history.moves = append(history.moves[:history.index], move)
+	history.index++
+}
+
+func isSameState(a, b CellState) bool {
+	if a.Value != b.Value || len(a.Notes) != len(b.Notes) {
+		return false
+	}
+
+	for i := range a.Notes {
+		if a.Notes[i] != b.Notes[i] {
+			return false
+		}
+	}
+
+	return true
+}
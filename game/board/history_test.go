@@ -0,0 +1,130 @@
+package board_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/serhatsdev/sudoku/game/board"
+)
+
+func TestHistoryUndoRedo(t *testing.T) {
+	history := board.NewHistory(getBoard())
+	history.Set(board.Point2{0, 0}, 6)
+	history.Set(board.Point2{0, 0}, 1)
+	history.Set(board.Point2{1, 0}, 1)
+	history.ToggleNote(board.Point2{2, 0}, 4)
+
+	if !history.Undo() || len(history.GetNotes(board.Point2{2, 0})) != 0 {
+		t.Errorf("History.Undo() failed to undo the note")
+	}
+	if !history.Undo() || history.Get(board.Point2{0, 0}) != 6 {
+		t.Errorf("History.Undo() failed to undo the value: %d",
+			history.Get(board.Point2{0, 0}))
+	}
+	if !history.Redo() || history.Get(board.Point2{0, 0}) != 1 {
+		t.Errorf("History.Redo() failed to redo the value: %d",
+			history.Get(board.Point2{0, 0}))
+	}
+
+	history.Undo()
+	history.Undo()
+	if history.Undo() || history.Get(board.Point2{0, 0}) != 0 {
+		t.Errorf("History.Undo() failed to stop at the first move")
+	}
+
+	history.Set(board.Point2{8, 8}, 9)
+	if history.CanRedo() {
+		t.Errorf("History.Set() failed to drop the undone moves")
+	}
+}
+
+func TestHistoryGroup(t *testing.T) {
+	history := board.NewHistory(getBoard())
+	history.ToggleNote(board.Point2{2, 0}, 4)
+	history.ToggleNote(board.Point2{3, 0}, 4)
+
+	history.BeginGroup()
+	history.Set(board.Point2{0, 0}, 4)
+	history.ToggleNote(board.Point2{2, 0}, 4)
+	history.ToggleNote(board.Point2{3, 0}, 4)
+	history.EndGroup()
+
+	moves, index := history.Moves()
+	if len(moves) != 3 || index != 3 {
+		t.Errorf("History.EndGroup() failed: Expected 3 moves, Actual: %d", len(moves))
+	}
+
+	history.Undo()
+	expected := []int{4}
+	if history.Get(board.Point2{0, 0}) != 0 ||
+		!reflect.DeepEqual(history.GetNotes(board.Point2{2, 0}), expected) ||
+		!reflect.DeepEqual(history.GetNotes(board.Point2{3, 0}), expected) {
+		t.Errorf("History.Undo() failed to undo the group")
+	}
+}
+
+func TestHistoryReopenGroup(t *testing.T) {
+	history := board.NewHistory(getBoard())
+	history.ToggleNote(board.Point2{2, 0}, 4)
+	history.Set(board.Point2{0, 0}, 3)
+
+	history.ReopenGroup()
+	history.Set(board.Point2{0, 0}, 4)
+	history.EndGroup()
+
+	moves, index := history.Moves()
+	if len(moves) != 2 || index != 2 {
+		t.Errorf("History.ReopenGroup() failed: Expected 2 moves, Actual: %d", len(moves))
+	}
+
+	history.Undo()
+	expected := []int{4}
+	if history.Get(board.Point2{0, 0}) != 0 ||
+		!reflect.DeepEqual(history.GetNotes(board.Point2{2, 0}), expected) {
+		t.Errorf("History.Undo() failed to undo the reopened group")
+	}
+}
+
+func TestHistoryIsLastMove(t *testing.T) {
+	history := board.NewHistory(getBoard())
+	history.Set(board.Point2{0, 0}, 3)
+	cycled := history.LastMove()
+	if !history.IsLastMove(cycled) {
+		t.Errorf("History.IsLastMove() failed: Expected: %v, Actual:%v", true, false)
+	}
+
+	// a new move at the same index after an undo is not the cycled move
+	history.Undo()
+	history.Set(board.Point2{0, 0}, 5)
+	if history.IsLastMove(cycled) {
+		t.Errorf("History.IsLastMove() failed: Expected: %v, Actual:%v", false, true)
+	}
+
+	// the wheel step starts a new move instead of reopening the new move
+	if history.IsLastMove(cycled) {
+		history.ReopenGroup()
+	} else {
+		history.BeginGroup()
+	}
+	history.Set(board.Point2{0, 0}, 6)
+	history.EndGroup()
+
+	moves, index := history.Moves()
+	if len(moves) != 2 || index != 2 {
+		t.Errorf("History.IsLastMove() failed: Expected: %v, Actual:%v", 2, len(moves))
+	}
+
+	history.Undo()
+	if history.Get(board.Point2{0, 0}) != 5 {
+		t.Errorf("History.Undo() failed: Expected: %v, Actual:%v", 5, history.Get(board.Point2{0, 0}))
+	}
+}
+
+func TestHistoryPredefined(t *testing.T) {
+	history := board.NewHistory(getBoard())
+	history.Set(board.Point2{1, 0}, 1)
+
+	if history.CanUndo() {
+		t.Errorf("History.Set() recorded a change of a predefined cell")
+	}
+}
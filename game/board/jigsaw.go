@@ -0,0 +1,353 @@
+package board
+
+import (
+	"errors"
+	"math/rand"
+)
+
+// ErrInvalidRegions is returned when the regions of a jigsaw board
+// do not cover the board with connected regions of the board size
+var ErrInvalidRegions = errors.New("jigsaw regions are not valid")
+
+// Regions is the constraint of the jigsaw boards, the irregular
+// regions replace the boxes and a value can not repeat within a region.
+// It holds the region index of every cell row by row.
+type Regions [][]int
+
+func (Regions) Rule() Rule {
+	return JigsawRule
+}
+
+func (regions Regions) Peers(pos Point2, size int) []Point2 {
+	peers := []Point2{}
+	for _, cPos := range regions.Cells(regions[pos.Y][pos.X]) {
+		if cPos != pos {
+			peers = append(peers, cPos)
+		}
+	}
+	return peers
+}
+
+func (regions Regions) Conflicts(grid Grid, pos Point2, value int) []Point2 {
+	return getPeerConflicts(grid, regions.Peers(pos, grid.Size()), value)
+}
+
+// Cells returns the positions of the cells of the region with the given index
+func (regions Regions) Cells(index int) []Point2 {
+	cells := []Point2{}
+	for i := range regions {
+		for j := range regions[i] {
+			if regions[i][j] == index {
+				cells = append(cells, Point2{j, i})
+			}
+		}
+	}
+	return cells
+}
+
+// isConnected returns if the cells of the region
+// with the given index are next to each other
+func (regions Regions) isConnected(index int) bool {
+	cells := regions.Cells(index)
+	if len(cells) == 0 {
+		return false
+	}
+
+	visited := []Point2{cells[0]}
+	for i := 0; i < len(visited); i++ {
+		for _, neighbour := range getNeighbours(visited[i], len(regions)) {
+			if regions[neighbour.Y][neighbour.X] == index && !containsPos(visited, neighbour) {
+				visited = append(visited, neighbour)
+			}
+		}
+	}
+	return len(visited) == len(cells)
+}
+
+// ValidateRegions returns ErrInvalidRegions if the regions do not split
+// a board with the given number of rows and columns into size connected
+// regions of size cells
+func ValidateRegions(regions Regions, size int) error {
+	if len(regions) != size {
+		return ErrInvalidRegions
+	}
+
+	counts := make([]int, size)
+	for _, row := range regions {
+		if len(row) != size {
+			return ErrInvalidRegions
+		}
+		for _, index := range row {
+			if index < 0 || index >= size {
+				return ErrInvalidRegions
+			}
+			counts[index]++
+		}
+	}
+
+	for index, count := range counts {
+		if count != size || !regions.isConnected(index) {
+			return ErrInvalidRegions
+		}
+	}
+	return nil
+}
+
+// getBoxRegions returns the boxes of the given dimensions as regions
+func getBoxRegions(dims Dimensions) Regions {
+	regions := make(Regions, dims.Size())
+	for i := range regions {
+		regions[i] = make([]int, dims.Size())
+		for j := range regions[i] {
+			regions[i][j] = dims.BoxIndex(Point2{j, i})
+		}
+	}
+	return regions
+}
+
+// jigsawMovesPerCell limits the moves tried for every cell of the board
+// while the regions are reshaped, maxJigsawAttempts is the number
+// of grids tried before the most reshaped regions are used
+const (
+	jigsawMovesPerCell = 50
+	maxJigsawAttempts  = 5
+)
+
+// maxCycleSteps limits the search of a cycle of regions
+const maxCycleSteps = 1000
+
+// getMinMovedCells returns the number of cells every jigsaw region
+// must take from the other boxes, a quarter of the board size
+func getMinMovedCells(dims Dimensions) int {
+	return (dims.Size() + 3) / 4
+}
+
+// countMovedCells returns the number of cells of every region
+// that are not in the box with the same index
+func (regions Regions) countMovedCells(dims Dimensions) []int {
+	moved := make([]int, dims.Size())
+	for i := range regions {
+		for j, index := range regions[i] {
+			if index != dims.BoxIndex(Point2{j, i}) {
+				moved[index]++
+			}
+		}
+	}
+	return moved
+}
+
+// generateJigsaw returns a complete grid with the given constraints
+// of the given rules and the jigsaw regions of the grid. Grids are
+// tried until every region takes getMinMovedCells cells from the
+// other boxes, the most reshaped regions are used otherwise.
+func generateJigsaw(dims Dimensions, rules Rule, constraints []Constraint, random *rand.Rand) (Grid, Regions) {
+	var best Grid
+	var bestRegions Regions
+	bestLeast := -1
+
+	for i := 0; i < maxJigsawAttempts; i++ {
+		complete := generateVariantGrid(dims, rules, constraints, random)
+		regions := generateRegions(complete, dims, constraints, random)
+		if least := getLeast(regions.countMovedCells(dims)); least > bestLeast {
+			best, bestRegions, bestLeast = complete, regions, least
+		}
+		if bestLeast >= getMinMovedCells(dims) {
+			break
+		}
+	}
+	return best, bestRegions
+}
+
+// generateRegions reshapes the boxes of the given complete grid by moving
+// cycles of cells with the same value around neighbour regions, so every
+// region keeps its values and stays connected. The values of the grid
+// are swapped in between to open new cycles, the grid keeps the given
+// constraints. It stops when every region takes getMinMovedCells cells
+// from the other boxes or after jigsawMovesPerCell moves for every cell.
+func generateRegions(complete Grid, dims Dimensions, constraints []Constraint, random *rand.Rand) Regions {
+	size := dims.Size()
+	regions := getBoxRegions(dims)
+
+	for i := 0; i < size*size*jigsawMovesPerCell; i++ {
+		moved := regions.countMovedCells(dims)
+		least := getLeast(moved)
+		if least >= getMinMovedCells(dims) {
+			break
+		}
+
+		if i%3 == 2 {
+			swapValues(complete, regions, constraints, random)
+			continue
+		}
+
+		// half of the cycles start at a region with the fewest moved cells
+		start := random.Intn(size)
+		if i%2 == 0 {
+			for _, index := range random.Perm(size) {
+				if moved[index] == least {
+					start = index
+					break
+				}
+			}
+		}
+		moveCycle(regions, complete, start, random.Intn(size)+1, random)
+	}
+	return regions
+}
+
+// getLeast returns the smallest of the given numbers
+func getLeast(numbers []int) int {
+	least := numbers[0]
+	for _, number := range numbers {
+		if number < least {
+			least = number
+		}
+	}
+	return least
+}
+
+// moveCycle moves the cell with the given value of the start region to
+// a neighbour region, the cell of that region with the value moves on
+// until a cell moves to the start region. It returns if a cycle is moved,
+// the cycles that break a region apart are not moved.
+func moveCycle(regions Regions, complete Grid, start, value int, random *rand.Rand) bool {
+	size := len(regions)
+	cells := make([]Point2, size)
+	for i := range complete {
+		for j := range complete[i] {
+			if complete[i][j] == value {
+				cells[regions[i][j]] = Point2{j, i}
+			}
+		}
+	}
+
+	visited := make([]bool, size)
+	visited[start] = true
+	steps := 0
+	return findCycle(regions, cells, []int{start}, visited, &steps, random)
+}
+
+// findCycle searches the cycles of regions that continue the given path
+// and moves the first one that keeps the regions connected
+func findCycle(regions Regions, cells []Point2, path []int, visited []bool, steps *int, random *rand.Rand) bool {
+	*steps++
+	if *steps > maxCycleSteps {
+		return false
+	}
+
+	last := path[len(path)-1]
+	targets := []int{}
+	for _, neighbour := range getNeighbours(cells[last], len(regions)) {
+		if region := regions[neighbour.Y][neighbour.X]; region != last {
+			targets = append(targets, region)
+		}
+	}
+	random.Shuffle(len(targets), func(i, j int) {
+		targets[i], targets[j] = targets[j], targets[i]
+	})
+
+	for _, target := range targets {
+		if target == path[0] && len(path) > 1 {
+			if moveRegionCells(regions, cells, path) {
+				return true
+			}
+			continue
+		}
+		if visited[target] {
+			continue
+		}
+
+		visited[target] = true
+		if findCycle(regions, cells, append(path, target), visited, steps, random) {
+			return true
+		}
+		visited[target] = false
+	}
+	return false
+}
+
+// moveRegionCells moves the cell of every region of the given cycle
+// to the next region, the regions are left unchanged if one of them
+// is broken apart
+func moveRegionCells(regions Regions, cells []Point2, cycle []int) bool {
+	for i, index := range cycle {
+		regions[cells[index].Y][cells[index].X] = cycle[(i+1)%len(cycle)]
+	}
+	for _, index := range cycle {
+		if !regions.isConnected(index) {
+			for _, index := range cycle {
+				regions[cells[index].Y][cells[index].X] = index
+			}
+			return false
+		}
+	}
+	return true
+}
+
+// swapValues swaps two values in a chain of cells, every row, column,
+// region and constraint peer of a cell in the chain that has one of
+// the values is in the chain, so the grid stays complete. The swap
+// is undone if the chain breaks a constraint without peers.
+// It returns if the values are swapped.
+func swapValues(grid Grid, regions Regions, constraints []Constraint, random *rand.Rand) bool {
+	size := len(grid)
+	a, b := random.Intn(size)+1, random.Intn(size)+1
+	if a == b {
+		return false
+	}
+
+	// the chain starts at the cell with the value a in a random row
+	row := random.Intn(size)
+	chain := []Point2{}
+	for j, value := range grid[row] {
+		if value == a {
+			chain = append(chain, Point2{j, row})
+		}
+	}
+
+	for i := 0; i < len(chain); i++ {
+		for _, peer := range getSwapPeers(chain[i], regions, constraints) {
+			if value := grid[peer.Y][peer.X]; (value == a || value == b) && !containsPos(chain, peer) {
+				chain = append(chain, peer)
+			}
+		}
+	}
+
+	// the chain of all cells with the values only renames the values
+	if len(chain) == 2*size {
+		return false
+	}
+	for _, pos := range chain {
+		grid[pos.Y][pos.X] = a + b - grid[pos.Y][pos.X]
+	}
+	for _, pos := range chain {
+		for _, constraint := range constraints {
+			if len(constraint.Conflicts(grid, pos, grid[pos.Y][pos.X])) > 0 {
+				for _, pos := range chain {
+					grid[pos.Y][pos.X] = a + b - grid[pos.Y][pos.X]
+				}
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// getSwapPeers returns the cells that can not have the same value
+// as the cell at the given position
+func getSwapPeers(pos Point2, regions Regions, constraints []Constraint) []Point2 {
+	size := len(regions)
+	peers := regions.Peers(pos, size)
+	for i := 0; i < size; i++ {
+		if i != pos.X {
+			peers = append(peers, Point2{i, pos.Y})
+		}
+		if i != pos.Y {
+			peers = append(peers, Point2{pos.X, i})
+		}
+	}
+	for _, constraint := range constraints {
+		peers = append(peers, constraint.Peers(pos, size)...)
+	}
+	return peers
+}
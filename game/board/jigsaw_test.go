@@ -0,0 +1,110 @@
+package board_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/serhatsdev/sudoku/game/board"
+)
+
+func TestValidateRegions(t *testing.T) {
+	tests := []struct {
+		name     string
+		regions  board.Regions
+		expected error
+	}{
+		{"boxes", board.Regions{{0, 0, 1, 1}, {0, 0, 1, 1}, {2, 2, 3, 3}, {2, 2, 3, 3}}, nil},
+		{"rows", board.Regions{{0, 0, 0, 0}, {1, 1, 1, 1}, {2, 2, 2, 2}, {3, 3, 3, 3}}, nil},
+		{"not connected", board.Regions{{0, 0, 1, 1}, {0, 1, 0, 1}, {2, 2, 3, 3}, {2, 2, 3, 3}}, board.ErrInvalidRegions},
+		{"wrong size", board.Regions{{0, 0, 0, 1}, {1, 1, 1, 1}, {2, 2, 2, 2}, {3, 3, 3, 0}}, board.ErrInvalidRegions},
+		{"out of range", board.Regions{{0, 0, 0, 0}, {1, 1, 1, 1}, {2, 2, 2, 2}, {3, 3, 3, 4}}, board.ErrInvalidRegions},
+		{"missing row", board.Regions{{0, 0, 0, 0}, {1, 1, 1, 1}, {2, 2, 2, 2}}, board.ErrInvalidRegions},
+	}
+
+	for _, test := range tests {
+		if actual := board.ValidateRegions(test.regions, 4); actual != test.expected {
+			t.Errorf("ValidateRegions(%s) failed: Expected: %v, Actual:%v", test.name, test.expected, actual)
+		}
+	}
+}
+
+func TestJigsawConflicts(t *testing.T) {
+	regions := board.Regions{
+		{0, 0, 0, 1},
+		{0, 1, 1, 1},
+		{2, 2, 2, 2},
+		{3, 3, 3, 3},
+	}
+	complete := board.Grid{
+		{1, 2, 3, 4},
+		{4, 1, 2, 3},
+		{2, 3, 4, 1},
+		{3, 4, 1, 2},
+	}
+	predefined := [][]bool{make([]bool, 4), make([]bool, 4), make([]bool, 4), make([]bool, 4)}
+	tBoard := board.NewCustom(board.NewGrid(4), complete, predefined, regions)
+
+	tBoard.Set(board.Point2{X: 1, Y: 1}, 1)
+	// the cells are in the same box but not in the same region
+	if conflicts := tBoard.GetConflicts(board.Point2{X: 0, Y: 0}, 1); len(conflicts) != 0 {
+		t.Errorf("GetConflicts() failed: Expected: no conflicts, Actual:%v", conflicts)
+	}
+	// the cells are in the same region but not in the same box
+	if _, exist := tBoard.GetConflicts(board.Point2{X: 3, Y: 0}, 1)[board.Point2{X: 1, Y: 1}]; !exist {
+		t.Errorf("GetConflicts() failed: Expected: conflict in the region")
+	}
+
+	if actual := tBoard.GetRegion(board.Point2{X: 3, Y: 0}); actual != 1 {
+		t.Errorf("GetRegion() failed: Expected: 1, Actual:%v", actual)
+	}
+	if actual := board.CountSolutions(complete, 2); actual != 0 {
+		t.Errorf("CountSolutions() failed: the grid breaks the classic boxes, Actual:%v", actual)
+	}
+	if err := board.ValidateConstraints(complete, regions); err != nil {
+		t.Errorf("ValidateConstraints() failed: Expected: <nil>, Actual:%v", err)
+	}
+}
+
+func TestNewVariantJigsaw(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	for _, dims := range board.Sizes {
+		tBoard, err := board.NewVariant(dims, board.Medium, board.Rotational, board.JigsawRule, random)
+		if err != nil {
+			t.Fatalf("board.NewVariant(%s) failed: %v", dims, err)
+		}
+		size := dims.Size()
+
+		regions := board.Regions{}
+		solution := board.NewGrid(size)
+		for i := 0; i < size; i++ {
+			regions = append(regions, make([]int, size))
+			for j := 0; j < size; j++ {
+				pos := board.Point2{X: j, Y: i}
+				regions[i][j] = tBoard.GetRegion(pos)
+				solution[i][j] = tBoard.GetCorrect(pos)
+			}
+		}
+
+		if err := board.ValidateRegions(regions, size); err != nil {
+			t.Errorf("board.NewVariant(%s) failed: %v", dims, err)
+		}
+		if err := board.ValidateConstraints(solution, regions); err != nil {
+			t.Errorf("board.NewVariant(%s) failed: the solution breaks the regions", dims)
+		}
+
+		// every region takes a quarter of the board size cells from the other boxes
+		moved := make([]int, size)
+		for i := range regions {
+			for j, index := range regions[i] {
+				if index != dims.BoxIndex(board.Point2{X: j, Y: i}) {
+					moved[index]++
+				}
+			}
+		}
+		for index, count := range moved {
+			if count < (size+3)/4 {
+				t.Errorf("board.NewVariant(%s) failed: Expected: at least %v moved cells in region %v, Actual:%v", dims, (size+3)/4, index, count)
+			}
+		}
+	}
+}
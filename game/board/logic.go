@@ -0,0 +1,886 @@
+package board
+
+import (
+	"math/bits"
+	"sync"
+)
+
+// Technique is a human style solving technique
+type Technique byte
+
+// Solving techniques, ordered from the easiest to the hardest
+const (
+	HiddenSingle Technique = iota
+	NakedSingle
+	Pointing
+	BoxLineReduction
+	NakedPair
+	HiddenPair
+	XWing
+	XYWing
+	Swordfish
+	XChain
+)
+
+// GuessRating is the rating of puzzles
+// that can not be solved with the known techniques
+const GuessRating = 100
+
+type techniqueInfo struct {
+	name   string
+	weight int
+	find   func(ls *LogicSolver) (Step, bool)
+}
+
+// techniques are tried in this order
+// while looking for the next step
+var techniques = []techniqueInfo{
+	HiddenSingle:     {"Hidden Single", 10, (*LogicSolver).findHiddenSingle},
+	NakedSingle:      {"Naked Single", 15, (*LogicSolver).findNakedSingle},
+	Pointing:         {"Pointing", 25, (*LogicSolver).findPointing},
+	BoxLineReduction: {"Box/Line Reduction", 28, (*LogicSolver).findBoxLineReduction},
+	NakedPair:        {"Naked Pair", 30, (*LogicSolver).findNakedPair},
+	HiddenPair:       {"Hidden Pair", 34, (*LogicSolver).findHiddenPair},
+	XWing:            {"X-Wing", 40, (*LogicSolver).findXWing},
+	XYWing:           {"XY-Wing", 45, (*LogicSolver).findXYWing},
+	Swordfish:        {"Swordfish", 50, (*LogicSolver).findSwordfish},
+	XChain:           {"X-Chain", 60, (*LogicSolver).findXChain},
+}
+
+// String returns the name of the technique
+func (technique Technique) String() string {
+	return techniques[technique].name
+}
+
+// Weight returns how hard the technique is
+func (technique Technique) Weight() int {
+	return techniques[technique].weight
+}
+
+// UnitKind is the kind of a unit
+type UnitKind byte
+
+// Unit kinds
+const (
+	RowUnit UnitKind = iota
+	ColumnUnit
+	BoxUnit
+)
+
+// Unit is a row, column or box of the board
+type Unit struct {
+	Kind  UnitKind
+	Index int
+}
+
+// Positions returns the positions of the cells in the unit of a board
+// with the given number of rows and columns, the boxes of the jigsaw
+// boards are their regions, see LogicSolver.Positions
+func (unit Unit) Positions(size int) []Point2 {
+	return getLayout(size).positions(unit)
+}
+
+// Candidate is a value that might be placed at a position
+type Candidate struct {
+	Pos   Point2
+	Value int
+}
+
+// Step is a single deduction made by the logic solver
+type Step struct {
+	Technique Technique
+	// Units are the rows, columns and boxes the deduction is based on
+	Units []Unit
+	// Cells are the cells that form the pattern of the technique
+	Cells []Point2
+	// Placements are the values the step places
+	Placements []Candidate
+	// Eliminations are the candidates the step removes
+	Eliminations []Candidate
+}
+
+// Grade is the result of solving a puzzle with the logic solver
+type Grade struct {
+	// Techniques maps the used techniques to the number of times used
+	Techniques map[Technique]int
+	// Rating is the weight of the hardest technique used,
+	// GuessRating if the puzzle can not be solved
+	Rating int
+	// Solved reports if the puzzle is solved by logic alone
+	Solved bool
+}
+
+// layout is the units and peers of the cells of a board size
+type layout struct {
+	dims Dimensions
+	size int
+	// regions are the box indexes of the cells
+	regions Regions
+	// units are the positions of the rows, columns and boxes by unit kind
+	units [3][][]Point2
+	// allUnits are the boxes, rows and columns in the order they are searched
+	allUnits []Unit
+	peers    [][][]Point2
+}
+
+var (
+	layoutsMutex sync.Mutex
+	layouts      = map[int]*layout{}
+)
+
+// getLayout returns the layout of the given board size,
+// the layouts are built once and shared
+func getLayout(size int) *layout {
+	layoutsMutex.Lock()
+	defer layoutsMutex.Unlock()
+
+	if l, exist := layouts[size]; exist {
+		return l
+	}
+
+	dims, err := DimensionsOf(size)
+	if err != nil {
+		panic(err)
+	}
+
+	l := newLayout(dims, getBoxRegions(dims))
+	layouts[size] = l
+	return l
+}
+
+// newLayout returns the layout of a board with the given regions as boxes
+func newLayout(dims Dimensions, regions Regions) *layout {
+	l := &layout{dims: dims, size: dims.Size(), regions: regions}
+
+	for kind := range l.units {
+		l.units[kind] = make([][]Point2, l.size)
+	}
+	for i := 0; i < l.size; i++ {
+		for j := 0; j < l.size; j++ {
+			l.units[RowUnit][i] = append(l.units[RowUnit][i], Point2{j, i})
+			l.units[ColumnUnit][i] = append(l.units[ColumnUnit][i], Point2{i, j})
+		}
+		l.units[BoxUnit][i] = regions.Cells(i)
+	}
+
+	for _, kind := range []UnitKind{BoxUnit, RowUnit, ColumnUnit} {
+		for i := 0; i < l.size; i++ {
+			l.allUnits = append(l.allUnits, Unit{kind, i})
+		}
+	}
+
+	l.peers = make([][][]Point2, l.size)
+	for i := 0; i < l.size; i++ {
+		l.peers[i] = make([][]Point2, l.size)
+		for j := 0; j < l.size; j++ {
+			pos := Point2{j, i}
+			for _, unit := range l.unitsOf(pos) {
+				for _, cPos := range l.positions(unit) {
+					if cPos != pos && !containsPos(l.peers[i][j], cPos) {
+						l.peers[i][j] = append(l.peers[i][j], cPos)
+					}
+				}
+			}
+		}
+	}
+
+	return l
+}
+
+func (l *layout) positions(unit Unit) []Point2 {
+	return l.units[unit.Kind][unit.Index]
+}
+
+// unitsOf returns the row, column and box of the given position
+func (l *layout) unitsOf(pos Point2) []Unit {
+	return []Unit{
+		{RowUnit, pos.Y},
+		{ColumnUnit, pos.X},
+		{BoxUnit, l.regions[pos.Y][pos.X]},
+	}
+}
+
+func (l *layout) sees(a, b Point2) bool {
+	return a != b && (a.X == b.X || a.Y == b.Y || l.regions[a.Y][a.X] == l.regions[b.Y][b.X])
+}
+
+// getConstraintLayout returns the layout of a board of the given size
+// with the given constraints, the regions of the jigsaw boards are the boxes
+func getConstraintLayout(size int, constraints []Constraint) *layout {
+	for _, constraint := range constraints {
+		if regions, ok := constraint.(Regions); ok {
+			dims, err := DimensionsOf(size)
+			if err != nil {
+				panic(err)
+			}
+			return newLayout(dims, regions)
+		}
+	}
+	return getLayout(size)
+}
+
+func containsPos(positions []Point2, pos Point2) bool {
+	for _, cPos := range positions {
+		if cPos == pos {
+			return true
+		}
+	}
+	return false
+}
+
+// maskValues returns the values in a candidate mask
+func maskValues(mask uint32) []int {
+	values := []int{}
+	for ; mask != 0; mask &= mask - 1 {
+		values = append(values, bits.TrailingZeros32(mask))
+	}
+	return values
+}
+
+// LogicSolver solves puzzles step by step with human style techniques
+type LogicSolver struct {
+	grid       Grid
+	layout     *layout
+	candidates [][]uint32
+	// constraints are the constraints other than the regions
+	constraints []Constraint
+}
+
+// NewLogicSolver returns a logic solver for the given puzzle, the grid must
+// have one of the supported sizes. The regions of the given constraints
+// replace the boxes, the other constraints remove the candidates that
+// break them but the techniques only search the rows, columns and boxes.
+func NewLogicSolver(grid Grid, constraints ...Constraint) *LogicSolver {
+	ls := &LogicSolver{grid: grid.Copy(), layout: getConstraintLayout(grid.Size(), constraints)}
+	for _, constraint := range constraints {
+		if _, ok := constraint.(Regions); !ok {
+			ls.constraints = append(ls.constraints, constraint)
+		}
+	}
+
+	ls.candidates = make([][]uint32, grid.Size())
+	for i := 0; i < grid.Size(); i++ {
+		ls.candidates[i] = make([]uint32, grid.Size())
+		for j := 0; j < grid.Size(); j++ {
+			if grid[i][j] != 0 {
+				continue
+			}
+
+			mask := getAllValuesMask(grid.Size())
+			for _, peer := range ls.layout.peers[i][j] {
+				mask &^= 1 << grid[peer.Y][peer.X]
+			}
+			ls.candidates[i][j] = mask
+		}
+	}
+	ls.removeConflicts()
+
+	return ls
+}
+
+// removeConflicts removes the candidates that break the constraints
+func (ls *LogicSolver) removeConflicts() {
+	if len(ls.constraints) == 0 {
+		return
+	}
+
+	for i := range ls.candidates {
+		for j := range ls.candidates[i] {
+			for mask := ls.candidates[i][j]; mask != 0; mask &= mask - 1 {
+				value := bits.TrailingZeros32(mask)
+				for _, constraint := range ls.constraints {
+					if len(constraint.Conflicts(ls.grid, Point2{j, i}, value)) > 0 {
+						ls.candidates[i][j] &^= 1 << value
+						break
+					}
+				}
+			}
+		}
+	}
+}
+
+// Positions returns the positions of the cells in the given unit
+func (ls *LogicSolver) Positions(unit Unit) []Point2 {
+	return ls.layout.positions(unit)
+}
+
+// Grid returns the current state of the puzzle
+func (ls *LogicSolver) Grid() Grid {
+	return ls.grid.Copy()
+}
+
+// Candidates returns the remaining candidates of the given cell
+func (ls *LogicSolver) Candidates(pos Point2) []int {
+	return maskValues(ls.candidates[pos.Y][pos.X])
+}
+
+// IsSolved returns if every cell of the puzzle is filled
+func (ls *LogicSolver) IsSolved() bool {
+	_, _, exist := findNextEmptyCell(ls.grid)
+	return !exist
+}
+
+// Next finds the next step with the easiest possible technique,
+// applies it and returns it. It returns false if no step is found.
+func (ls *LogicSolver) Next() (Step, bool) {
+	for _, technique := range techniques {
+		if step, found := technique.find(ls); found {
+			ls.Apply(step)
+			return step, true
+		}
+	}
+
+	return Step{}, false
+}
+
+// NextPlacement applies steps until a step places a value.
+// It returns the applied steps, the last one is the placing step.
+// It returns false if no placing step is found.
+func (ls *LogicSolver) NextPlacement() ([]Step, bool) {
+	steps := []Step{}
+	for {
+		step, found := ls.Next()
+		if !found {
+			return steps, false
+		}
+
+		steps = append(steps, step)
+		if len(step.Placements) > 0 {
+			return steps, true
+		}
+	}
+}
+
+// Apply applies the placements and eliminations of the given step
+func (ls *LogicSolver) Apply(step Step) {
+	for _, candidate := range step.Eliminations {
+		ls.candidates[candidate.Pos.Y][candidate.Pos.X] &^= 1 << candidate.Value
+	}
+
+	for _, candidate := range step.Placements {
+		ls.place(candidate.Pos, candidate.Value)
+	}
+}
+
+func (ls *LogicSolver) place(pos Point2, value int) {
+	ls.grid[pos.Y][pos.X] = value
+	ls.candidates[pos.Y][pos.X] = 0
+
+	for _, peer := range ls.layout.peers[pos.Y][pos.X] {
+		ls.candidates[peer.Y][peer.X] &^= 1 << value
+	}
+	ls.removeConflicts()
+}
+
+func (ls *LogicSolver) hasCandidate(pos Point2, value int) bool {
+	return ls.candidates[pos.Y][pos.X]&(1<<value) != 0
+}
+
+// positionsOf returns positions in the unit that have the given candidate
+func (ls *LogicSolver) positionsOf(unit Unit, value int) []Point2 {
+	positions := []Point2{}
+	for _, pos := range ls.layout.positions(unit) {
+		if ls.hasCandidate(pos, value) {
+			positions = append(positions, pos)
+		}
+	}
+	return positions
+}
+
+func (ls *LogicSolver) findHiddenSingle() (Step, bool) {
+	for _, unit := range ls.layout.allUnits {
+		for value := 1; value <= ls.layout.size; value++ {
+			positions := ls.positionsOf(unit, value)
+			if len(positions) == 1 {
+				return Step{
+					Technique:  HiddenSingle,
+					Units:      []Unit{unit},
+					Cells:      positions,
+					Placements: []Candidate{{positions[0], value}},
+				}, true
+			}
+		}
+	}
+
+	return Step{}, false
+}
+
+func (ls *LogicSolver) findNakedSingle() (Step, bool) {
+	for i := 0; i < ls.layout.size; i++ {
+		for j := 0; j < ls.layout.size; j++ {
+			mask := ls.candidates[i][j]
+			if bits.OnesCount32(mask) != 1 {
+				continue
+			}
+
+			pos := Point2{j, i}
+			return Step{
+				Technique:  NakedSingle,
+				Units:      ls.layout.unitsOf(pos),
+				Cells:      []Point2{pos},
+				Placements: []Candidate{{pos, maskValues(mask)[0]}},
+			}, true
+		}
+	}
+
+	return Step{}, false
+}
+
+func (ls *LogicSolver) findPointing() (Step, bool) {
+	for i := 0; i < ls.layout.size; i++ {
+		box := Unit{BoxUnit, i}
+		for value := 1; value <= ls.layout.size; value++ {
+			positions := ls.positionsOf(box, value)
+			if len(positions) < 2 {
+				continue
+			}
+
+			for _, line := range ls.getCommonLines(positions) {
+				eliminations := ls.eliminationsOf(ls.layout.positions(line), value, positions)
+				if len(eliminations) > 0 {
+					return Step{
+						Technique:    Pointing,
+						Units:        []Unit{box, line},
+						Cells:        positions,
+						Eliminations: eliminations,
+					}, true
+				}
+			}
+		}
+	}
+
+	return Step{}, false
+}
+
+func (ls *LogicSolver) findBoxLineReduction() (Step, bool) {
+	for _, line := range ls.layout.allUnits[ls.layout.size:] {
+		for value := 1; value <= ls.layout.size; value++ {
+			positions := ls.positionsOf(line, value)
+			if len(positions) < 2 {
+				continue
+			}
+
+			box := Unit{BoxUnit, ls.layout.regions[positions[0].Y][positions[0].X]}
+			if !ls.allInUnit(positions, box) {
+				continue
+			}
+
+			eliminations := ls.eliminationsOf(ls.layout.positions(box), value, positions)
+			if len(eliminations) > 0 {
+				return Step{
+					Technique:    BoxLineReduction,
+					Units:        []Unit{line, box},
+					Cells:        positions,
+					Eliminations: eliminations,
+				}, true
+			}
+		}
+	}
+
+	return Step{}, false
+}
+
+func (ls *LogicSolver) findNakedPair() (Step, bool) {
+	for _, unit := range ls.layout.allUnits {
+		positions := ls.layout.positions(unit)
+		for a := 0; a < len(positions); a++ {
+			mask := ls.candidates[positions[a].Y][positions[a].X]
+			if bits.OnesCount32(mask) != 2 {
+				continue
+			}
+
+			for b := a + 1; b < len(positions); b++ {
+				if ls.candidates[positions[b].Y][positions[b].X] != mask {
+					continue
+				}
+
+				pair := []Point2{positions[a], positions[b]}
+				eliminations := []Candidate{}
+				for _, value := range maskValues(mask) {
+					eliminations = append(eliminations,
+						ls.eliminationsOf(positions, value, pair)...)
+				}
+
+				if len(eliminations) > 0 {
+					return Step{
+						Technique:    NakedPair,
+						Units:        []Unit{unit},
+						Cells:        pair,
+						Eliminations: eliminations,
+					}, true
+				}
+			}
+		}
+	}
+
+	return Step{}, false
+}
+
+func (ls *LogicSolver) findHiddenPair() (Step, bool) {
+	for _, unit := range ls.layout.allUnits {
+		for a := 1; a <= ls.layout.size; a++ {
+			pair := ls.positionsOf(unit, a)
+			if len(pair) != 2 {
+				continue
+			}
+
+			for b := a + 1; b <= ls.layout.size; b++ {
+				bPositions := ls.positionsOf(unit, b)
+				if len(bPositions) != 2 || bPositions[0] != pair[0] || bPositions[1] != pair[1] {
+					continue
+				}
+
+				eliminations := []Candidate{}
+				for _, pos := range pair {
+					for _, value := range ls.Candidates(pos) {
+						if value != a && value != b {
+							eliminations = append(eliminations, Candidate{pos, value})
+						}
+					}
+				}
+
+				if len(eliminations) > 0 {
+					return Step{
+						Technique:    HiddenPair,
+						Units:        []Unit{unit},
+						Cells:        pair,
+						Eliminations: eliminations,
+					}, true
+				}
+			}
+		}
+	}
+
+	return Step{}, false
+}
+
+func (ls *LogicSolver) findXWing() (Step, bool) {
+	return ls.findFish(XWing, 2)
+}
+
+func (ls *LogicSolver) findSwordfish() (Step, bool) {
+	return ls.findFish(Swordfish, 3)
+}
+
+// findFish looks for size base lines whose candidates of a value
+// are confined to size cover lines, the value is then eliminated
+// from the rest of the cover lines
+func (ls *LogicSolver) findFish(technique Technique, size int) (Step, bool) {
+	for _, kinds := range [][2]UnitKind{{RowUnit, ColumnUnit}, {ColumnUnit, RowUnit}} {
+		baseKind, coverKind := kinds[0], kinds[1]
+
+		for value := 1; value <= ls.layout.size; value++ {
+			bases := []Unit{}
+			for i := 0; i < ls.layout.size; i++ {
+				count := len(ls.positionsOf(Unit{baseKind, i}, value))
+				if count >= 2 && count <= size {
+					bases = append(bases, Unit{baseKind, i})
+				}
+			}
+
+			for _, combination := range getCombinations(len(bases), size) {
+				cells := []Point2{}
+				covers := []int{}
+				for _, index := range combination {
+					for _, pos := range ls.positionsOf(bases[index], value) {
+						cells = append(cells, pos)
+						if lineIndex := getLineIndex(pos, coverKind); !containsInt(covers, lineIndex) {
+							covers = append(covers, lineIndex)
+						}
+					}
+				}
+				if len(covers) != size {
+					continue
+				}
+
+				units := []Unit{}
+				eliminations := []Candidate{}
+				for _, index := range combination {
+					units = append(units, bases[index])
+				}
+				for _, coverIndex := range covers {
+					cover := Unit{coverKind, coverIndex}
+					units = append(units, cover)
+					eliminations = append(eliminations,
+						ls.eliminationsOf(ls.layout.positions(cover), value, cells)...)
+				}
+
+				if len(eliminations) > 0 {
+					return Step{
+						Technique:    technique,
+						Units:        units,
+						Cells:        cells,
+						Eliminations: eliminations,
+					}, true
+				}
+			}
+		}
+	}
+
+	return Step{}, false
+}
+
+func (ls *LogicSolver) findXYWing() (Step, bool) {
+	for i := 0; i < ls.layout.size; i++ {
+		for j := 0; j < ls.layout.size; j++ {
+			pivot := Point2{j, i}
+			pivotMask := ls.candidates[i][j]
+			if bits.OnesCount32(pivotMask) != 2 {
+				continue
+			}
+
+			pincers := []Point2{}
+			for _, peer := range ls.layout.peers[i][j] {
+				mask := ls.candidates[peer.Y][peer.X]
+				if bits.OnesCount32(mask) == 2 && bits.OnesCount32(mask&pivotMask) == 1 {
+					pincers = append(pincers, peer)
+				}
+			}
+
+			for a := 0; a < len(pincers); a++ {
+				for b := a + 1; b < len(pincers); b++ {
+					aMask := ls.candidates[pincers[a].Y][pincers[a].X]
+					bMask := ls.candidates[pincers[b].Y][pincers[b].X]
+
+					common := aMask & bMask &^ pivotMask
+					if bits.OnesCount32(common) != 1 || (aMask|bMask)&pivotMask != pivotMask {
+						continue
+					}
+
+					value := maskValues(common)[0]
+					eliminations := []Candidate{}
+					for _, pos := range ls.layout.peers[pincers[a].Y][pincers[a].X] {
+						if pos != pivot && ls.layout.sees(pos, pincers[b]) && ls.hasCandidate(pos, value) {
+							eliminations = append(eliminations, Candidate{pos, value})
+						}
+					}
+
+					if len(eliminations) > 0 {
+						return Step{
+							Technique:    XYWing,
+							Cells:        []Point2{pivot, pincers[a], pincers[b]},
+							Eliminations: eliminations,
+						}, true
+					}
+				}
+			}
+		}
+	}
+
+	return Step{}, false
+}
+
+// findXChain colors the chains of conjugate pairs of a value.
+// If two cells with the same color see each other, that color is false.
+// Cells that see both colors can not have the value.
+func (ls *LogicSolver) findXChain() (Step, bool) {
+	for value := 1; value <= ls.layout.size; value++ {
+		links := map[Point2][]Point2{}
+		for _, unit := range ls.layout.allUnits {
+			positions := ls.positionsOf(unit, value)
+			if len(positions) == 2 {
+				links[positions[0]] = append(links[positions[0]], positions[1])
+				links[positions[1]] = append(links[positions[1]], positions[0])
+			}
+		}
+
+		colored := map[Point2]struct{}{}
+		for i := 0; i < ls.layout.size; i++ {
+			for j := 0; j < ls.layout.size; j++ {
+				start := Point2{j, i}
+				if _, done := colored[start]; done || len(links[start]) == 0 {
+					continue
+				}
+
+				colors := colorChain(links, start)
+				for pos := range colors {
+					colored[pos] = struct{}{}
+				}
+
+				if step, found := ls.checkChain(value, colors); found {
+					return step, true
+				}
+			}
+		}
+	}
+
+	return Step{}, false
+}
+
+func (ls *LogicSolver) checkChain(value int, colors map[Point2]bool) (Step, bool) {
+	cells := []Point2{}
+	for pos := range colors {
+		cells = append(cells, pos)
+	}
+
+	// color wrap
+	for a, color := range colors {
+		for b, bColor := range colors {
+			if color != bColor || !ls.layout.sees(a, b) {
+				continue
+			}
+
+			eliminations := []Candidate{}
+			for pos, pColor := range colors {
+				if pColor == color {
+					eliminations = append(eliminations, Candidate{pos, value})
+				}
+			}
+
+			return Step{
+				Technique:    XChain,
+				Cells:        cells,
+				Eliminations: eliminations,
+			}, true
+		}
+	}
+
+	// color trap
+	eliminations := []Candidate{}
+	for i := 0; i < ls.layout.size; i++ {
+		for j := 0; j < ls.layout.size; j++ {
+			pos := Point2{j, i}
+			if _, inChain := colors[pos]; inChain || !ls.hasCandidate(pos, value) {
+				continue
+			}
+
+			seesColor := [2]bool{}
+			for cPos, color := range colors {
+				if ls.layout.sees(pos, cPos) {
+					seesColor[boolToIndex(color)] = true
+				}
+			}
+
+			if seesColor[0] && seesColor[1] {
+				eliminations = append(eliminations, Candidate{pos, value})
+			}
+		}
+	}
+
+	if len(eliminations) > 0 {
+		return Step{
+			Technique:    XChain,
+			Cells:        cells,
+			Eliminations: eliminations,
+		}, true
+	}
+
+	return Step{}, false
+}
+
+// colorChain colors the cells connected to start with alternating colors
+func colorChain(links map[Point2][]Point2, start Point2) map[Point2]bool {
+	colors := map[Point2]bool{start: true}
+	queue := []Point2{start}
+
+	for len(queue) > 0 {
+		pos := queue[0]
+		queue = queue[1:]
+
+		for _, next := range links[pos] {
+			if _, done := colors[next]; !done {
+				colors[next] = !colors[pos]
+				queue = append(queue, next)
+			}
+		}
+	}
+
+	return colors
+}
+
+func boolToIndex(value bool) int {
+	if value {
+		return 1
+	}
+	return 0
+}
+
+// eliminationsOf returns the candidates of the given value
+// in positions, except the ones in excluded
+func (ls *LogicSolver) eliminationsOf(positions []Point2, value int, excluded []Point2) []Candidate {
+	eliminations := []Candidate{}
+	for _, pos := range positions {
+		if ls.hasCandidate(pos, value) && !containsPos(excluded, pos) {
+			eliminations = append(eliminations, Candidate{pos, value})
+		}
+	}
+	return eliminations
+}
+
+// getCommonLines returns the row and column that
+// contain all of the given positions, if there are any
+func (ls *LogicSolver) getCommonLines(positions []Point2) []Unit {
+	lines := []Unit{}
+	for _, line := range []Unit{{RowUnit, positions[0].Y}, {ColumnUnit, positions[0].X}} {
+		if ls.allInUnit(positions, line) {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+func (ls *LogicSolver) allInUnit(positions []Point2, unit Unit) bool {
+	for _, pos := range positions {
+		if !containsPos(ls.layout.positions(unit), pos) {
+			return false
+		}
+	}
+	return true
+}
+
+func containsInt(values []int, value int) bool {
+	for _, cValue := range values {
+		if cValue == value {
+			return true
+		}
+	}
+	return false
+}
+
+func getLineIndex(pos Point2, kind UnitKind) int {
+	if kind == RowUnit {
+		return pos.Y
+	}
+	return pos.X
+}
+
+// getCombinations returns all size element combinations of 0..n-1
+func getCombinations(n, size int) [][]int {
+	if size == 0 {
+		return [][]int{{}}
+	}
+
+	combinations := [][]int{}
+	for last := size - 1; last < n; last++ {
+		for _, combination := range getCombinations(last, size-1) {
+			combinations = append(combinations, append(combination, last))
+		}
+	}
+	return combinations
+}
+
+// GradeGrid solves the given puzzle with the logic solver
+// and reports the techniques it needs
+func GradeGrid(grid Grid) Grade {
+	ls := NewLogicSolver(grid)
+	grade := Grade{Techniques: map[Technique]int{}}
+
+	for {
+		step, found := ls.Next()
+		if !found {
+			break
+		}
+
+		grade.Techniques[step.Technique]++
+		if step.Technique.Weight() > grade.Rating {
+			grade.Rating = step.Technique.Weight()
+		}
+	}
+
+	grade.Solved = ls.IsSolved()
+	if !grade.Solved {
+		grade.Rating = GuessRating
+	}
+
+	return grade
+}
@@ -0,0 +1,142 @@
+package board_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/serhatsdev/sudoku/game/board"
+)
+
+func TestGradeGrid(t *testing.T) {
+	tests := []struct {
+		name      string
+		grid      board.Grid
+		solved    bool
+		maxRating int
+	}{
+		{
+			name:      "easy",
+			grid:      getGrid(getBoard()),
+			solved:    true,
+			maxRating: board.NakedSingle.Weight(),
+		},
+		{
+			name:      "needs guessing",
+			grid:      gridFromString("8.........36......7..9.2...5...7.......457.....1...3...1....68..85...1..9....4.."),
+			solved:    false,
+			maxRating: board.GuessRating,
+		},
+	}
+
+	for _, test := range tests {
+		actual := board.GradeGrid(test.grid)
+		if actual.Solved != test.solved || actual.Rating > test.maxRating {
+			t.Errorf("GradeGrid(%s) failed: Expected: %v <= %d, Actual: %v %d",
+				test.name, test.solved, test.maxRating, actual.Solved, actual.Rating)
+		}
+	}
+}
+
+func TestLogicSolverSteps(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	for i := 0; i < 5; i++ {
+		tBoard := board.New(board.Classic, board.VeryHard, board.NoSymmetry, random)
+		ls := board.NewLogicSolver(getGrid(tBoard))
+
+		for step, found := ls.Next(); found; step, found = ls.Next() {
+			for _, candidate := range step.Eliminations {
+				if tBoard.GetCorrect(candidate.Pos) == candidate.Value {
+					t.Fatalf("%v eliminated the correct value %v", step.Technique, candidate)
+				}
+			}
+
+			for _, candidate := range step.Placements {
+				if tBoard.GetCorrect(candidate.Pos) != candidate.Value {
+					t.Fatalf("%v placed a wrong value %v", step.Technique, candidate)
+				}
+			}
+		}
+	}
+}
+
+func TestLogicSolverVariantSteps(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	rules := []board.Rule{board.KillerRule, board.DiagonalRule, board.NonConsecutiveRule, board.EvenOddRule}
+	for _, rule := range rules {
+		tBoard, err := board.NewVariant(board.Classic, board.VeryHard, board.NoSymmetry, rule, random)
+		if err != nil {
+			t.Fatalf("board.NewVariant(%v) failed: %v", rule, err)
+		}
+		ls := board.NewLogicSolver(getGrid(tBoard), tBoard.Constraints()...)
+
+		for i := 0; i < board.Classic.Size(); i++ {
+			for j := 0; j < board.Classic.Size(); j++ {
+				pos := board.Point2{X: j, Y: i}
+				if tBoard.Get(pos) == 0 && !containsValue(ls.Candidates(pos), tBoard.GetCorrect(pos)) {
+					t.Fatalf("NewLogicSolver(%v) removed the correct value of %v", rule, pos)
+				}
+			}
+		}
+
+		for step, found := ls.Next(); found; step, found = ls.Next() {
+			for _, candidate := range step.Placements {
+				if tBoard.GetCorrect(candidate.Pos) != candidate.Value {
+					t.Fatalf("%v placed a wrong value %v with %v", step.Technique, candidate, rule)
+				}
+			}
+		}
+	}
+}
+
+func containsValue(values []int, value int) bool {
+	for _, cValue := range values {
+		if cValue == value {
+			return true
+		}
+	}
+	return false
+}
+
+func TestNextPlacement(t *testing.T) {
+	tBoard := getBoard()
+	ls := board.NewLogicSolver(getGrid(tBoard))
+
+	for !ls.IsSolved() {
+		steps, found := ls.NextPlacement()
+		if !found {
+			t.Fatalf("NextPlacement() failed to find a placement")
+		}
+
+		last := steps[len(steps)-1]
+		for _, step := range steps[:len(steps)-1] {
+			if len(step.Placements) > 0 {
+				t.Fatalf("NextPlacement() applied more than one placement")
+			}
+		}
+		if len(last.Placements) == 0 {
+			t.Fatalf("NextPlacement() returned without a placement")
+		}
+	}
+
+	if _, found := ls.NextPlacement(); found {
+		t.Errorf("NextPlacement() found a placement in a solved grid")
+	}
+}
+
+func TestTechniqueString(t *testing.T) {
+	tests := []struct {
+		technique board.Technique
+		expected  string
+	}{
+		{board.HiddenSingle, "Hidden Single"},
+		{board.XWing, "X-Wing"},
+		{board.XChain, "X-Chain"},
+	}
+
+	for _, test := range tests {
+		if actual := test.technique.String(); actual != test.expected {
+			t.Errorf("Technique.String() failed: Expected: %s, Actual: %s",
+				test.expected, actual)
+		}
+	}
+}
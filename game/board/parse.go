@@ -0,0 +1,148 @@
+package board
+
+import (
+	"errors"
+	"strings"
+)
+
+// ErrInvalidFormat is returned when a puzzle text
+// is not in the expected format
+var ErrInvalidFormat = errors.New("puzzle is not in a known format")
+
+// ErrMultipleSolutions is returned when a grid has more than one solution
+var ErrMultipleSolutions = errors.New("grid has more than one solution")
+
+// ParseLine parses the line format, the cells are listed row by row
+// with '.' or '0' for the empty cells. The length of the line
+// decides the size of the board, such as 81 characters for 9x9.
+func ParseLine(text string) (Grid, error) {
+	line := strings.TrimSpace(text)
+	for _, dims := range Sizes {
+		if len(line) == dims.Size()*dims.Size() {
+			return parseRows(splitEvery(line, dims.Size()), ".0")
+		}
+	}
+
+	return nil, ErrInvalidFormat
+}
+
+// ParseMultiLine parses a grid with a row on every line. The '|', '+', '-'
+// and space characters between the cells and the separator lines
+// are ignored, '.' or '0' are the empty cells.
+func ParseMultiLine(text string) (Grid, error) {
+	rows := []string{}
+	for _, line := range strings.Split(text, "\n") {
+		row := strings.Map(func(char rune) rune {
+			if strings.ContainsRune("|+- \t\r", char) {
+				return -1
+			}
+			return char
+		}, line)
+
+		if row != "" {
+			rows = append(rows, row)
+		}
+	}
+
+	return parseRows(rows, ".0")
+}
+
+// ParseSDK parses the SadMan Software .sdk format, the lines starting
+// with '#' are the puzzle information and the grid is given with
+// a row on every line and '.' for the empty cells
+func ParseSDK(text string) (Grid, error) {
+	rows := []string{}
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		rows = append(rows, line)
+	}
+
+	return parseRows(rows, ".")
+}
+
+// ParseSS parses the Simple Sudoku .ss format, the boxes are separated
+// with '|' and lines of '-', '.' or 'X' are the empty cells
+func ParseSS(text string) (Grid, error) {
+	rows := []string{}
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.Trim(line, "-") == "" {
+			continue
+		}
+
+		rows = append(rows, strings.ReplaceAll(line, "|", ""))
+	}
+
+	return parseRows(rows, ".Xx")
+}
+
+// Parse parses the puzzle text in the line, .sdk, .ss or
+// multi line format, the first format that fits is used
+func Parse(text string) (Grid, error) {
+	parsers := []func(string) (Grid, error){ParseLine, ParseSDK, ParseSS, ParseMultiLine}
+	for _, parser := range parsers {
+		grid, err := parser(text)
+		if err == nil {
+			return grid, nil
+		}
+	}
+
+	return nil, ErrInvalidFormat
+}
+
+// NewFromGrid returns a new board with the given grid as its
+// predefined values. The grid must have a unique solution.
+func NewFromGrid(grid Grid) (Board, error) {
+	solution, err := Solve(grid)
+	if err != nil {
+		return nil, err
+	}
+	if CountSolutions(grid, 2) > 1 {
+		return nil, ErrMultipleSolutions
+	}
+
+	return NewCustom(grid, solution, getPredefined(grid)), nil
+}
+
+// parseRows parses the rows of a grid, every row must have
+// a character for each cell, a symbol or one of the blanks.
+// The number of rows decides the size of the board.
+func parseRows(rows []string, blanks string) (Grid, error) {
+	size := len(rows)
+	if _, err := DimensionsOf(size); err != nil {
+		return nil, ErrInvalidFormat
+	}
+
+	grid := NewGrid(size)
+	for i, row := range rows {
+		if len(row) != size {
+			return nil, ErrInvalidFormat
+		}
+
+		for j, char := range row {
+			if strings.ContainsRune(blanks, char) {
+				continue
+			}
+
+			value := SymbolValue(char)
+			if value == 0 || value > size {
+				return nil, ErrInvalidFormat
+			}
+			grid[i][j] = value
+		}
+	}
+
+	return grid, nil
+}
+
+func splitEvery(text string, length int) []string {
+	parts := []string{}
+	for i := 0; i < len(text); i += length {
+		parts = append(parts, text[i:i+length])
+	}
+	return parts
+}
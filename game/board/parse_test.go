@@ -0,0 +1,139 @@
+package board_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/serhatsdev/sudoku/game/board"
+)
+
+var parsePuzzle = board.Grid{
+	{0, 2, 0, 0, 9, 0, 5, 8, 0},
+	{7, 5, 0, 8, 4, 0, 9, 3, 2},
+	{8, 0, 9, 1, 2, 0, 0, 4, 0},
+	{4, 0, 0, 0, 5, 0, 2, 1, 6},
+	{0, 7, 6, 3, 0, 2, 0, 0, 5},
+	{5, 0, 2, 0, 0, 0, 8, 7, 0},
+	{0, 6, 0, 0, 3, 4, 1, 0, 8},
+	{2, 1, 8, 5, 0, 9, 0, 0, 4},
+	{3, 4, 0, 0, 0, 8, 7, 2, 0},
+}
+
+func TestParse(t *testing.T) {
+	line := ".2..9.58.75.84.9328.912..4.4...5.216.763.2..55.2...87..6..341.82185.9..434...872."
+
+	multiLine := `
+0 2 0 | 0 9 0 | 5 8 0
+7 5 0 | 8 4 0 | 9 3 2
+8 0 9 | 1 2 0 | 0 4 0
+------+-------+------
+4 0 0 | 0 5 0 | 2 1 6
+0 7 6 | 3 0 2 | 0 0 5
+5 0 2 | 0 0 0 | 8 7 0
+------+-------+------
+0 6 0 | 0 3 4 | 1 0 8
+2 1 8 | 5 0 9 | 0 0 4
+3 4 0 | 0 0 8 | 7 2 0
+`
+
+	sdk := `#A Author
+#D Description
+.2..9.58.
+75.84.932
+8.912..4.
+4...5.216
+.763.2..5
+5.2...87.
+.6..341.8
+2185.9..4
+34...872.
+`
+
+	ss := `.2.|.9.|58.
+75.|84.|932
+8.9|12.|.4.
+-----------
+4..|.5.|216
+.76|3.2|..5
+5.2|...|87.
+-----------
+.6.|.34|1.8
+218|5.9|..4
+34.|..8|72X
+`
+
+	tests := []struct {
+		name   string
+		parser func(string) (board.Grid, error)
+		text   string
+	}{
+		{"ParseLine", board.ParseLine, line},
+		{"ParseMultiLine", board.ParseMultiLine, multiLine},
+		{"ParseSDK", board.ParseSDK, sdk},
+		{"ParseSS", board.ParseSS, ss},
+		{"Parse line", board.Parse, line},
+		{"Parse multi line", board.Parse, multiLine},
+		{"Parse sdk", board.Parse, sdk},
+		{"Parse ss", board.Parse, ss},
+	}
+
+	for _, test := range tests {
+		actual, err := test.parser(test.text)
+		if err != nil || !reflect.DeepEqual(actual, parsePuzzle) {
+			t.Errorf("%s() failed: Expected: %v, Actual:%v, Error: %v",
+				test.name, parsePuzzle, actual, err)
+		}
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		parser func(string) (board.Grid, error)
+		text   string
+	}{
+		{"short line", board.ParseLine, "123"},
+		{"letter in line", board.ParseLine, "a" + string(make([]byte, 80))},
+		{"missing row", board.ParseSDK, ".2..9.58.\n75.84.932\n"},
+		{"unknown", board.Parse, "not a sudoku"},
+	}
+
+	for _, test := range tests {
+		_, err := test.parser(test.text)
+		if err != board.ErrInvalidFormat {
+			t.Errorf("%s failed: Expected: %v, Actual:%v",
+				test.name, board.ErrInvalidFormat, err)
+		}
+	}
+}
+
+func TestNewFromGrid(t *testing.T) {
+	unsolvable := parsePuzzle.Copy()
+	unsolvable[0][0] = 1
+
+	conflicting := parsePuzzle.Copy()
+	conflicting[0][0] = 2
+
+	tests := []struct {
+		name     string
+		grid     board.Grid
+		expected error
+	}{
+		{"unique", parsePuzzle, nil},
+		{"empty", board.NewGrid(9), board.ErrMultipleSolutions},
+		{"unsolvable", unsolvable, board.ErrNoSolution},
+		{"conflicting", conflicting, board.ErrInvalidGrid},
+	}
+
+	for _, test := range tests {
+		b, err := board.NewFromGrid(test.grid)
+		if err != test.expected {
+			t.Errorf("NewFromGrid(%s) failed: Expected: %v, Actual:%v",
+				test.name, test.expected, err)
+		}
+
+		if err == nil && (!reflect.DeepEqual(getGrid(b), test.grid) || b.IsPredefined(board.Point2{X: 0, Y: 0})) {
+			t.Errorf("NewFromGrid(%s) failed to keep the given values", test.name)
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package board
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidPuzzleID is returned when a puzzle id can not be parsed
+var ErrInvalidPuzzleID = errors.New("puzzle id is not valid")
+
+// maxSeed keeps the seeds of the puzzle ids 8 characters long
+const maxSeed = 36 * 36 * 36 * 36 * 36 * 36 * 36 * 36
+
+// PuzzleID identifies a generated puzzle, generating
+// the same id always returns the same puzzle
+type PuzzleID struct {
+	// Size is the number of rows and columns of the board
+	Size       int
+	Difficulty byte
+	Symmetry   Symmetry
+	Seed       int64
+	// Rules are the variant rules of the puzzle, 0 for the classic puzzles
+	Rules Rule
+}
+
+// NewPuzzleID returns a new puzzle id with a random seed
+func NewPuzzleID(size int, difficulty byte, symmetry Symmetry, rules Rule) PuzzleID {
+	random := rand.New(rand.NewSource(time.Now().UnixNano()))
+	return PuzzleID{size, difficulty, symmetry, random.Int63n(maxSeed), rules}
+}
+
+// ParsePuzzleID parses the text form of a puzzle id, see PuzzleID.String
+func ParsePuzzleID(text string) (PuzzleID, error) {
+	parts := strings.Split(strings.TrimSpace(text), "-")
+
+	size := Classic.Size()
+	if len(parts) > 0 && strings.ContainsAny(parts[0], "xX") && strings.ContainsAny(parts[0], "0123456789") {
+		var err error
+		size, err = parseSize(parts[0])
+		if err != nil {
+			return PuzzleID{}, ErrInvalidPuzzleID
+		}
+		parts = parts[1:]
+	}
+
+	rules := Rule(0)
+	if len(parts) > 0 && parts[0] != "" && !strings.ContainsAny(parts[0], "0123456789") {
+		var err error
+		rules, err = ParseRuleCodes(parts[0])
+		if err != nil {
+			return PuzzleID{}, ErrInvalidPuzzleID
+		}
+		parts = parts[1:]
+	}
+
+	if len(parts) != 2 && len(parts) != 3 {
+		return PuzzleID{}, ErrInvalidPuzzleID
+	}
+
+	symmetry := NoSymmetry
+	if len(parts) == 3 {
+		var exist bool
+		symmetry, exist = parseSymmetryCode(parts[2])
+		if !exist {
+			return PuzzleID{}, ErrInvalidPuzzleID
+		}
+	}
+
+	difficulty, err := strconv.ParseUint(parts[0], 10, 8)
+	if err != nil || difficulty == 0 || difficulty > uint64(Classic.Size()*Classic.Size()) {
+		return PuzzleID{}, ErrInvalidPuzzleID
+	}
+	seed, err := strconv.ParseInt(parts[1], 36, 64)
+	if err != nil || seed < 0 || seed >= maxSeed {
+		return PuzzleID{}, ErrInvalidPuzzleID
+	}
+
+	return PuzzleID{size, byte(difficulty), symmetry, seed, rules}, nil
+}
+
+// parseSize parses a board size such as "6x6"
+func parseSize(text string) (int, error) {
+	sizes := strings.Split(strings.ToLower(text), "x")
+	if len(sizes) != 2 || sizes[0] != sizes[1] {
+		return 0, ErrInvalidSize
+	}
+
+	size, err := strconv.Atoi(sizes[0])
+	if err != nil {
+		return 0, ErrInvalidSize
+	}
+	if _, err := DimensionsOf(size); err != nil {
+		return 0, err
+	}
+	return size, nil
+}
+
+// String returns the id as the difficulty, the seed in base 36
+// and the letter of the symmetry if there is one, such as "40-1K3ZQ8WA-R".
+// The ids of the variants start with the letters of the rules, such as "KX-40-1K3ZQ8WA",
+// the ids of the boards other than 9x9 start with the size, such as "6x6-40-1K3ZQ8WA".
+func (id PuzzleID) String() string {
+	text := fmt.Sprintf("%d-%s", id.Difficulty, strings.ToUpper(strconv.FormatInt(id.Seed, 36)))
+	if id.Rules != 0 {
+		text = id.Rules.Code() + "-" + text
+	}
+	if id.Size != Classic.Size() {
+		text = fmt.Sprintf("%dx%d-%s", id.Size, id.Size, text)
+	}
+	if code, exist := symmetryCodes[id.Symmetry]; exist {
+		text += "-" + code
+	}
+	return text
+}
+
+// Generate returns the puzzle of the given id, the size of the id must be
+// one of Sizes. It returns the error of ValidateRules if the rules
+// of the id are not supported on the size of the id.
+func Generate(id PuzzleID) (Board, error) {
+	dims, err := DimensionsOf(id.Size)
+	if err != nil {
+		return nil, err
+	}
+	return NewVariant(dims, id.Difficulty, id.Symmetry, id.Rules, rand.New(rand.NewSource(id.Seed)))
+}
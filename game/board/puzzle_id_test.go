@@ -0,0 +1,82 @@
+package board_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/serhatsdev/sudoku/game/board"
+)
+
+func TestParsePuzzleID(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected board.PuzzleID
+		err      error
+	}{
+		{"40-1K3ZQ8WA", board.PuzzleID{Size: 9, Difficulty: 40, Seed: 122141220490}, nil},
+		{" 20-0 ", board.PuzzleID{Size: 9, Difficulty: 20, Seed: 0}, nil},
+		{"40-1k3zq8wa", board.PuzzleID{Size: 9, Difficulty: 40, Seed: 122141220490}, nil},
+		{"40", board.PuzzleID{}, board.ErrInvalidPuzzleID},
+		{"0-1K3ZQ8WA", board.PuzzleID{}, board.ErrInvalidPuzzleID},
+		{"40-1K3ZQ8WA1", board.PuzzleID{}, board.ErrInvalidPuzzleID},
+		{"40-?", board.PuzzleID{}, board.ErrInvalidPuzzleID},
+		{"40-1K3ZQ8WA-R", board.PuzzleID{Size: 9, Difficulty: 40, Symmetry: board.Rotational, Seed: 122141220490}, nil},
+		{"40-1K3ZQ8WA-d", board.PuzzleID{Size: 9, Difficulty: 40, Symmetry: board.Diagonal, Seed: 122141220490}, nil},
+		{"40-1K3ZQ8WA-X", board.PuzzleID{}, board.ErrInvalidPuzzleID},
+		{"6x6-40-1K3ZQ8WA", board.PuzzleID{Size: 6, Difficulty: 40, Seed: 122141220490}, nil},
+		{"16X16-40-1K3ZQ8WA-R", board.PuzzleID{Size: 16, Difficulty: 40, Symmetry: board.Rotational, Seed: 122141220490}, nil},
+		{"5x5-40-1K3ZQ8WA", board.PuzzleID{}, board.ErrInvalidPuzzleID},
+		{"6x4-40-1K3ZQ8WA", board.PuzzleID{}, board.ErrInvalidPuzzleID},
+		{"40-1K3ZQ8WA-", board.PuzzleID{}, board.ErrInvalidPuzzleID},
+		{"K-40-1K3ZQ8WA", board.PuzzleID{Size: 9, Difficulty: 40, Seed: 122141220490, Rules: board.KillerRule}, nil},
+		{"6x6-k-40-1K3ZQ8WA-M", board.PuzzleID{Size: 6, Difficulty: 40, Symmetry: board.Mirror, Seed: 122141220490, Rules: board.KillerRule}, nil},
+		{"K-6x6-40-1K3ZQ8WA", board.PuzzleID{}, board.ErrInvalidPuzzleID},
+		{"xn-40-1K3ZQ8WA", board.PuzzleID{Size: 9, Difficulty: 40, Seed: 122141220490, Rules: board.DiagonalRule | board.AntiKnightRule}, nil},
+		{"KQ-40-1K3ZQ8WA", board.PuzzleID{}, board.ErrInvalidPuzzleID},
+	}
+
+	for _, test := range tests {
+		actual, err := board.ParsePuzzleID(test.text)
+		if actual != test.expected || err != test.err {
+			t.Errorf("ParsePuzzleID(%q) failed: Expected: %v, Actual:%v, Error: %v",
+				test.text, test.expected, actual, err)
+		}
+	}
+
+	for _, dims := range board.Sizes {
+		for _, rules := range []board.Rule{0, board.KillerRule | board.EvenOddRule} {
+			id := board.NewPuzzleID(dims.Size(), board.Hard, board.Mirror, rules)
+			if actual, err := board.ParsePuzzleID(id.String()); actual != id || err != nil {
+				t.Errorf("ParsePuzzleID(%s) failed: Expected: %v, Actual:%v", id, id, actual)
+			}
+		}
+	}
+}
+
+func generate(t *testing.T, id board.PuzzleID) board.Board {
+	b, err := board.Generate(id)
+	if err != nil {
+		t.Fatalf("Generate(%s) failed: %v", id, err)
+	}
+	return b
+}
+
+func TestGenerate(t *testing.T) {
+	id := board.PuzzleID{Size: 9, Difficulty: board.Hard, Seed: 42}
+
+	expected := getGrid(generate(t, id))
+	if actual := getGrid(generate(t, id)); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Generate(%s) failed: Expected: %v, Actual:%v", id, expected, actual)
+	}
+
+	other := getGrid(generate(t, board.PuzzleID{Size: 9, Difficulty: board.Hard, Seed: 43}))
+	if reflect.DeepEqual(other, expected) {
+		t.Errorf("Generate(%s) failed: the seed did not change the puzzle", id)
+	}
+
+	killerID := board.PuzzleID{Size: 9, Difficulty: board.Hard, Seed: 42, Rules: board.KillerRule}
+	expectedCages := generate(t, killerID).Cages()
+	if actual := generate(t, killerID).Cages(); len(actual) == 0 || !reflect.DeepEqual(actual, expectedCages) {
+		t.Errorf("Generate(%s) failed: Expected: %v, Actual:%v", killerID, expectedCages, actual)
+	}
+}
@@ -0,0 +1,442 @@
+package board
+
+import (
+	"errors"
+	"math/bits"
+	"math/rand"
+)
+
+// ErrInvalidGrid is returned when a grid has values out of range
+// or the same value more than once in a row, column or box
+var ErrInvalidGrid = errors.New("grid has invalid or conflicting values")
+
+// ErrNoSolution is returned when a grid can not be solved
+var ErrNoSolution = errors.New("grid has no solution")
+
+// getAllValuesMask returns a mask with the bits
+// of every value of the given board size set
+func getAllValuesMask(size int) uint32 {
+	return uint32(1)<<(size+1) - 2
+}
+
+// solver is a backtracking solver that keeps the used values
+// of every row, column, box and cage as bitmasks,
+// the other constraints are checked by their peers and conflicts
+type solver struct {
+	grid    Grid
+	dims    Dimensions
+	rows    []uint32
+	columns []uint32
+	boxes   []uint32
+	// regions are the box indexes of the cells,
+	// the regions of the jigsaw grids replace the boxes
+	regions Regions
+
+	cages       []solverCage
+	cageIndexes [][]int
+	// sumValues caches getSumValues by the remaining
+	// sum, empty cells and unused values of the cages
+	sumValues map[[3]int]uint32
+	// peers are the peers of the constraints of every cell,
+	// nil if the constraints have no peers
+	peers [][][]Point2
+	// nonConsecutive keeps the neighbour cells from having consecutive values
+	nonConsecutive bool
+	// parities are the allowed values of the even and odd cells,
+	// nil if there are no even or odd cells
+	parities [][]uint32
+	// checks are the other constraints, they are checked by their conflicts
+	checks []Constraint
+	// random tries the values in random order if it is not nil
+	random *rand.Rand
+
+	limit int
+	count int
+	// maxSteps stops the search after the given number of
+	// placements if it is not 0, aborted reports if it is stopped
+	maxSteps   int
+	steps      int
+	aborted    bool
+	solution   Grid
+	emptyCells []Point2
+}
+
+// solverCage is the state of a cage while solving
+type solverCage struct {
+	// remaining is the sum of the empty cells
+	remaining int
+	empty     int
+	used      uint32
+}
+
+func newSolver(grid Grid, constraints []Constraint) (*solver, error) {
+	dims, err := grid.Dimensions()
+	if err != nil {
+		return nil, ErrInvalidGrid
+	}
+
+	size := dims.Size()
+	s := &solver{
+		grid:    grid.Copy(),
+		dims:    dims,
+		rows:    make([]uint32, size),
+		columns: make([]uint32, size),
+		boxes:   make([]uint32, size),
+		regions: getLayout(size).regions,
+	}
+
+	for _, constraint := range constraints {
+		if regions, ok := constraint.(Regions); ok {
+			if err := ValidateRegions(regions, size); err != nil {
+				return nil, err
+			}
+			s.regions = regions
+		}
+	}
+
+	for i := 0; i < size; i++ {
+		for j := 0; j < size; j++ {
+			value := grid[i][j]
+			if value == 0 {
+				s.emptyCells = append(s.emptyCells, Point2{j, i})
+				continue
+			}
+
+			if value < 0 || value > size {
+				return nil, ErrInvalidGrid
+			}
+
+			bit := uint32(1) << value
+			box := s.regions[i][j]
+			if (s.rows[i]|s.columns[j]|s.boxes[box])&bit != 0 {
+				return nil, ErrInvalidGrid
+			}
+
+			s.rows[i] |= bit
+			s.columns[j] |= bit
+			s.boxes[box] |= bit
+		}
+	}
+
+	for _, constraint := range constraints {
+		err = s.addConstraint(constraint)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return s, nil
+}
+
+// addConstraint adds the given constraint to the solver,
+// the values of the grid must not break it
+func (s *solver) addConstraint(constraint Constraint) error {
+	size := s.dims.Size()
+	if cages, ok := constraint.(Cages); ok {
+		return s.addCages(cages)
+	}
+
+	switch constraint := constraint.(type) {
+	case Regions:
+		// the regions are the boxes of the solver
+		return nil
+	case Diagonals, Windoku, AntiKnight, AntiKing:
+	case NonConsecutive:
+		s.nonConsecutive = true
+	case EvenOdd:
+		if err := s.addEvenOdd(constraint); err != nil {
+			return err
+		}
+	default:
+		s.checks = append(s.checks, constraint)
+	}
+
+	if s.peers == nil {
+		s.peers = make([][][]Point2, size)
+		for i := range s.peers {
+			s.peers[i] = make([][]Point2, size)
+		}
+	}
+
+	for i := 0; i < size; i++ {
+		for j := 0; j < size; j++ {
+			pos := Point2{j, i}
+			s.peers[i][j] = append(s.peers[i][j], constraint.Peers(pos, size)...)
+
+			if value := s.grid[i][j]; value != 0 && len(constraint.Conflicts(s.grid, pos, value)) > 0 {
+				return ErrInvalidGrid
+			}
+		}
+	}
+
+	return nil
+}
+
+// addEvenOdd adds the even and odd cells to the solver
+func (s *solver) addEvenOdd(evenOdd EvenOdd) error {
+	size := s.dims.Size()
+	even, odd := uint32(0), uint32(0)
+	for value := 1; value <= size; value++ {
+		if value%2 == 0 {
+			even |= 1 << value
+		} else {
+			odd |= 1 << value
+		}
+	}
+
+	if s.parities == nil {
+		s.parities = make([][]uint32, size)
+		for i := range s.parities {
+			s.parities[i] = make([]uint32, size)
+			for j := range s.parities[i] {
+				s.parities[i][j] = getAllValuesMask(size)
+			}
+		}
+	}
+
+	for _, cells := range [][]Point2{evenOdd.Even, evenOdd.Odd} {
+		for _, pos := range cells {
+			if pos.X < 0 || pos.Y < 0 || pos.X >= size || pos.Y >= size {
+				return ErrInvalidGrid
+			}
+		}
+	}
+
+	for _, pos := range evenOdd.Even {
+		s.parities[pos.Y][pos.X] &= even
+	}
+	for _, pos := range evenOdd.Odd {
+		s.parities[pos.Y][pos.X] &= odd
+	}
+	return nil
+}
+
+// addCages adds the cages of a killer grid to the solver
+func (s *solver) addCages(cages Cages) error {
+	indexes, err := getCageIndexes(cages, s.dims.Size())
+	if err != nil {
+		return err
+	}
+
+	s.cageIndexes = indexes
+	s.sumValues = map[[3]int]uint32{}
+	for _, cage := range cages {
+		sCage := solverCage{remaining: cage.Sum}
+		for _, pos := range cage.Cells {
+			value := s.grid[pos.Y][pos.X]
+			if value == 0 {
+				sCage.empty++
+				continue
+			}
+
+			bit := uint32(1) << value
+			if sCage.used&bit != 0 {
+				return ErrInvalidGrid
+			}
+			sCage.used |= bit
+			sCage.remaining -= value
+		}
+
+		if sCage.remaining < 0 || (sCage.empty == 0 && sCage.remaining != 0) {
+			return ErrInvalidGrid
+		}
+		s.cages = append(s.cages, sCage)
+	}
+
+	return nil
+}
+
+func (s *solver) candidates(pos Point2) uint32 {
+	mask := getAllValuesMask(s.dims.Size()) &^ (s.rows[pos.Y] | s.columns[pos.X] | s.boxes[s.regions[pos.Y][pos.X]])
+	if s.peers != nil {
+		for _, peer := range s.peers[pos.Y][pos.X] {
+			mask &^= 1 << s.grid[peer.Y][peer.X]
+		}
+	}
+
+	if s.nonConsecutive {
+		for _, neighbour := range getNeighbours(pos, s.dims.Size()) {
+			if value := s.grid[neighbour.Y][neighbour.X]; value != 0 {
+				mask &^= 1<<(value-1) | 1<<(value+1)
+			}
+		}
+	}
+
+	if s.parities != nil {
+		mask &= s.parities[pos.Y][pos.X]
+	}
+
+	for _, constraint := range s.checks {
+		for values := mask; values != 0; values &= values - 1 {
+			value := bits.TrailingZeros32(values)
+			if len(constraint.Conflicts(s.grid, pos, value)) > 0 {
+				mask &^= 1 << value
+			}
+		}
+	}
+
+	if s.cageIndexes == nil || s.cageIndexes[pos.Y][pos.X] == -1 {
+		return mask
+	}
+
+	cage := s.cages[s.cageIndexes[pos.Y][pos.X]]
+	available := getAllValuesMask(s.dims.Size()) &^ cage.used
+	key := [3]int{cage.remaining, cage.empty, int(available)}
+	values, exist := s.sumValues[key]
+	if !exist {
+		values = getSumValues(cage.remaining, cage.empty, available)
+		s.sumValues[key] = values
+	}
+	return mask & values
+}
+
+// toggle places the value to the given cell,
+// or takes it back if it is already placed
+func (s *solver) toggle(pos Point2, value int) {
+	bit := uint32(1) << value
+	s.grid[pos.Y][pos.X] = value
+	s.rows[pos.Y] ^= bit
+	s.columns[pos.X] ^= bit
+	s.boxes[s.regions[pos.Y][pos.X]] ^= bit
+
+	if s.cageIndexes == nil || s.cageIndexes[pos.Y][pos.X] == -1 {
+		return
+	}
+
+	cage := &s.cages[s.cageIndexes[pos.Y][pos.X]]
+	if cage.used&bit == 0 {
+		cage.remaining -= value
+		cage.empty--
+	} else {
+		cage.remaining += value
+		cage.empty++
+	}
+	cage.used ^= bit
+}
+
+// search fills the empty cells starting from the given index,
+// choosing the cell with the fewest candidates first
+func (s *solver) search(index int) {
+	if index == len(s.emptyCells) {
+		if s.count == 0 {
+			s.solution = s.grid.Copy()
+		}
+		s.count++
+		return
+	}
+
+	best, bestMask, bestCount := index, uint32(0), s.dims.Size()+1
+	for i := index; i < len(s.emptyCells); i++ {
+		mask := s.candidates(s.emptyCells[i])
+		if count := bits.OnesCount32(mask); count < bestCount {
+			best, bestMask, bestCount = i, mask, count
+			if count < 2 {
+				break
+			}
+		}
+	}
+
+	s.emptyCells[index], s.emptyCells[best] = s.emptyCells[best], s.emptyCells[index]
+	pos := s.emptyCells[index]
+
+	// the values are tried ascending from the mask bits
+	// unless the solver has a random source
+	if s.random != nil {
+		for _, value := range s.shuffledValues(bestMask) {
+			if !s.tryValue(index, pos, value) {
+				break
+			}
+		}
+	} else {
+		for mask := bestMask; mask != 0; mask &= mask - 1 {
+			if !s.tryValue(index, pos, bits.TrailingZeros32(mask)) {
+				break
+			}
+		}
+	}
+	s.grid[pos.Y][pos.X] = 0
+}
+
+// tryValue searches the rest of the empty cells with the given value
+// at the given position, it returns false if the search is over
+func (s *solver) tryValue(index int, pos Point2, value int) bool {
+	if s.count >= s.limit || s.aborted {
+		return false
+	}
+
+	s.steps++
+	if s.maxSteps != 0 && s.steps > s.maxSteps {
+		s.aborted = true
+		return false
+	}
+
+	s.toggle(pos, value)
+	s.search(index + 1)
+	s.toggle(pos, value)
+	return true
+}
+
+// shuffledValues returns the values of the mask in random order
+func (s *solver) shuffledValues(mask uint32) []int {
+	values := maskValues(mask)
+	s.random.Shuffle(len(values), func(i, j int) {
+		values[i], values[j] = values[j], values[i]
+	})
+	return values
+}
+
+// Solve returns a solution of the given grid.
+// It returns ErrInvalidGrid if the grid breaks the rules
+// and ErrNoSolution if the grid can not be solved.
+func Solve(grid Grid) (Grid, error) {
+	s, err := newSolver(grid, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	s.limit = 1
+	s.search(0)
+	if s.count == 0 {
+		return nil, ErrNoSolution
+	}
+
+	return s.solution, nil
+}
+
+// CountSolutions returns the number of solutions of the given grid.
+// It stops searching as soon as limit solutions are found,
+// so CountSolutions(grid, 2) is enough to check uniqueness.
+// Grids that break the rules have no solutions.
+func CountSolutions(grid Grid, limit int) int {
+	s, err := newSolver(grid, nil)
+	if err != nil {
+		return 0
+	}
+
+	s.limit = limit
+	s.search(0)
+	return s.count
+}
+
+// ValidateConstraints returns ErrInvalidGrid if the values
+// of the given grid break the rules or the given constraints
+func ValidateConstraints(grid Grid, constraints ...Constraint) error {
+	_, err := newSolver(grid, constraints)
+	return err
+}
+
+// hasUniqueSolution returns if the given grid has exactly one solution
+// with the given constraints, the classic grids have no constraints.
+// The search is limited to maxSteps placements if it is not 0,
+// grids that can not be checked within the limit are reported as not unique.
+func hasUniqueSolution(grid Grid, constraints []Constraint, maxSteps int) bool {
+	s, err := newSolver(grid, constraints)
+	if err != nil {
+		return false
+	}
+
+	s.limit = 2
+	s.maxSteps = maxSteps
+	s.search(0)
+	return s.count == 1 && !s.aborted
+}
@@ -0,0 +1,138 @@
+package board_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/serhatsdev/sudoku/game/board"
+)
+
+func TestCountSolutions(t *testing.T) {
+	unique := board.Grid{
+		{0, 2, 0, 0, 9, 0, 5, 8, 0},
+		{7, 5, 0, 8, 4, 0, 9, 3, 2},
+		{8, 0, 9, 1, 2, 0, 0, 4, 0},
+		{4, 0, 0, 0, 5, 0, 2, 1, 6},
+		{0, 7, 6, 3, 0, 2, 0, 0, 5},
+		{5, 0, 2, 0, 0, 0, 8, 7, 0},
+		{0, 6, 0, 0, 3, 4, 1, 0, 8},
+		{2, 1, 8, 5, 0, 9, 0, 0, 4},
+		{3, 4, 0, 0, 0, 8, 7, 2, 0},
+	}
+
+	// 6 and 7 can be swapped in the empty cells
+	// of the fifth and seventh rows
+	ambiguous := board.Grid{
+		{6, 2, 4, 7, 9, 3, 5, 8, 1},
+		{7, 5, 1, 8, 4, 6, 9, 3, 2},
+		{8, 3, 9, 1, 2, 5, 6, 4, 7},
+		{4, 8, 3, 9, 5, 7, 2, 1, 6},
+		{1, 0, 0, 3, 8, 2, 4, 9, 5},
+		{5, 9, 2, 4, 6, 1, 8, 7, 3},
+		{9, 0, 0, 2, 3, 4, 1, 5, 8},
+		{2, 1, 8, 5, 7, 9, 3, 6, 4},
+		{3, 4, 5, 6, 1, 8, 7, 2, 9},
+	}
+
+	contradictory := unique.Copy()
+	contradictory[0][0] = 2
+
+	unsolvable := unique.Copy()
+	unsolvable[0][0] = 1
+
+	tests := []struct {
+		name     string
+		grid     board.Grid
+		limit    int
+		expected int
+	}{
+		{"unique", unique, 2, 1},
+		{"ambiguous", ambiguous, 10, 2},
+		{"ambiguous limited", ambiguous, 1, 1},
+		{"empty limited", board.NewGrid(9), 5, 5},
+		{"empty 4x4", board.NewGrid(4), 300, 288},
+		{"wrong size", board.NewGrid(5), 2, 0},
+		{"contradictory", contradictory, 2, 0},
+		{"unsolvable", unsolvable, 2, 0},
+	}
+
+	for _, test := range tests {
+		actual := board.CountSolutions(test.grid, test.limit)
+		if test.expected != actual {
+			t.Errorf("CountSolutions(%s) failed: Expected: %d, Actual:%d",
+				test.name, test.expected, actual)
+		}
+	}
+}
+
+func TestSolve(t *testing.T) {
+	solution := getSolutionGrid(getBoard())
+
+	contradictory := getGrid(getBoard())
+	contradictory[0][0] = 2
+
+	outOfRange := getGrid(getBoard())
+	outOfRange[0][0] = 10
+
+	unsolvable := getGrid(getBoard())
+	unsolvable[0][0] = 1
+
+	tests := []struct {
+		name     string
+		grid     board.Grid
+		expected board.Grid
+		err      error
+	}{
+		{"puzzle", getGrid(getBoard()), solution, nil},
+		{"solved", solution, solution, nil},
+		{"contradictory", contradictory, nil, board.ErrInvalidGrid},
+		{"out of range", outOfRange, nil, board.ErrInvalidGrid},
+		{"unsolvable", unsolvable, nil, board.ErrNoSolution},
+	}
+
+	for _, test := range tests {
+		actual, err := board.Solve(test.grid)
+		if err != test.err || !reflect.DeepEqual(actual, test.expected) {
+			t.Errorf("Solve(%s) failed: Expected: %v %v, Actual: %v %v",
+				test.name, test.expected, test.err, actual, err)
+		}
+	}
+
+	for _, dims := range board.Sizes {
+		empty, err := board.Solve(board.NewGrid(dims.Size()))
+		if err != nil || board.CountSolutions(empty, 2) != 1 {
+			t.Errorf("Solve(empty %s) failed: %v %v", dims, empty, err)
+		}
+	}
+}
+
+var benchmarkPuzzles = []string{
+	"..9.7...5..21..9..1...28....7...5..1..851.....5....3.......3..68........21.....87",
+	"4.....8.5.3..........7......2.....6.....8.4......1.......6.3.7.5..2.....1.4......",
+	"52...6.........7.13...........4..8..6......5...........418.........3..2...87.....",
+	"8.........36......7..9.2...5...7.......457.....1...3...1....68..85...1..9....4..",
+}
+
+func BenchmarkSolve(b *testing.B) {
+	grids := []board.Grid{}
+	for _, puzzle := range benchmarkPuzzles {
+		grids = append(grids, gridFromString(puzzle))
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		board.Solve(grids[i%len(grids)])
+	}
+}
+
+func BenchmarkCountSolutions(b *testing.B) {
+	grids := []board.Grid{}
+	for _, puzzle := range benchmarkPuzzles {
+		grids = append(grids, gridFromString(puzzle))
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		board.CountSolutions(grids[i%len(grids)], 2)
+	}
+}
@@ -0,0 +1,89 @@
+package board
+
+import (
+	"errors"
+	"strings"
+)
+
+// Symmetry is the layout of the clues of a generated puzzle,
+// the cells are removed together with their symmetric cells
+type Symmetry byte
+
+const (
+	NoSymmetry = Symmetry(iota)
+	// Rotational keeps the clues the same
+	// when the board is rotated by 180 degrees
+	Rotational
+	// Mirror keeps the clues the same
+	// when the board is flipped left to right
+	Mirror
+	// Diagonal keeps the clues the same when the board
+	// is flipped along the main diagonal
+	Diagonal
+)
+
+// ErrUnknownSymmetry is returned when a symmetry name can not be parsed
+var ErrUnknownSymmetry = errors.New("unknown symmetry")
+
+// Symmetries are all symmetries in menu order
+var Symmetries = []Symmetry{NoSymmetry, Rotational, Mirror, Diagonal}
+
+var symmetryNames = map[Symmetry]string{
+	NoSymmetry: "None",
+	Rotational: "Rotational",
+	Mirror:     "Mirror",
+	Diagonal:   "Diagonal",
+}
+
+// symmetryCodes are the letters of the symmetries in puzzle ids
+var symmetryCodes = map[Symmetry]string{
+	Rotational: "R",
+	Mirror:     "M",
+	Diagonal:   "D",
+}
+
+// ParseSymmetry returns the symmetry of the given name
+func ParseSymmetry(name string) (Symmetry, error) {
+	for _, symmetry := range Symmetries {
+		if strings.EqualFold(name, symmetry.String()) {
+			return symmetry, nil
+		}
+	}
+	return NoSymmetry, ErrUnknownSymmetry
+}
+
+// parseSymmetryCode returns the symmetry of the given puzzle id code
+func parseSymmetryCode(code string) (Symmetry, bool) {
+	for symmetry, symmetryCode := range symmetryCodes {
+		if strings.EqualFold(code, symmetryCode) {
+			return symmetry, true
+		}
+	}
+	return NoSymmetry, false
+}
+
+// String returns the name of the symmetry
+func (symmetry Symmetry) String() string {
+	return symmetryNames[symmetry]
+}
+
+// getOrbit returns the given position and the positions
+// symmetric to it on a board of the given size
+func (symmetry Symmetry) getOrbit(pos Point2, size int) []Point2 {
+	var other Point2
+	switch symmetry {
+	case Rotational:
+		other = Point2{size - 1 - pos.X, size - 1 - pos.Y}
+	case Mirror:
+		other = Point2{size - 1 - pos.X, pos.Y}
+	case Diagonal:
+		other = Point2{pos.Y, pos.X}
+	default:
+		return []Point2{pos}
+	}
+
+	if other == pos {
+		return []Point2{pos}
+	}
+	return []Point2{pos, other}
+}
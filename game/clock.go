@@ -0,0 +1,52 @@
+package game
+
+import (
+	"fmt"
+	"time"
+)
+
+// clock measures the elapsed play time, it can be paused and resumed
+type clock struct {
+	elapsed   time.Duration
+	startedAt time.Time
+	running   bool
+}
+
+// Resume starts measuring the time if the clock is paused
+func (c *clock) Resume() {
+	if !c.running {
+		c.startedAt = time.Now()
+		c.running = true
+	}
+}
+
+// Pause stops measuring the time if the clock is running
+func (c *clock) Pause() {
+	if c.running {
+		c.elapsed += time.Since(c.startedAt)
+		c.running = false
+	}
+}
+
+// Elapsed returns the total measured time
+func (c *clock) Elapsed() time.Duration {
+	if c.running {
+		return c.elapsed + time.Since(c.startedAt)
+	}
+	return c.elapsed
+}
+
+// Reset sets the measured time to the given duration
+func (c *clock) Reset(elapsed time.Duration) {
+	c.elapsed = elapsed
+	c.startedAt = time.Now()
+}
+
+// formatDuration formats the duration as MM:SS, or H:MM:SS after an hour
+func formatDuration(duration time.Duration) string {
+	seconds := int(duration.Seconds())
+	if seconds >= 3600 {
+		return fmt.Sprintf("%d:%02d:%02d", seconds/3600, seconds/60%60, seconds%60)
+	}
+	return fmt.Sprintf("%02d:%02d", seconds/60, seconds%60)
+}
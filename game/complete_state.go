@@ -0,0 +1,59 @@
+package game
+
+import (
+	"fmt"
+
+	"github.com/serhatsdev/sudoku/game/ui"
+)
+
+// completeState shows the results of the solved board
+// with the options to continue
+type completeState struct {
+	menuState
+}
+
+func (cs *completeState) OnKeyPress(key string) {
+	// an option must be chosen
+	if key == "esc" {
+		return
+	}
+
+	cs.menuState.OnKeyPress(key)
+}
+
+func (cs *completeState) Draw() {
+	results := fmt.Sprintf("Solved!\n\nDifficulty: %s\nTime: %s\nMistakes: %d\nHints: %d",
+		DifficultyName(cs.Game.Difficulty()),
+		formatDuration(cs.Game.Elapsed()),
+		cs.Game.MistakeCount(),
+		cs.Game.HintCount(),
+	)
+
+	cs.widget = &ui.MenuWidget{
+		Options:     getTitlesFromOptions(cs.Options),
+		CursorIndex: cs.Pos,
+		HAlign:      ui.HAlignCenter,
+		Color:       cs.Game.Theme().Menu,
+		Cursor:      cs.Game.Theme().MenuCursor,
+	}
+
+	cs.Game.Client().DrawCenter(&ui.BoxWidget{
+		Child: &ui.ColumnWidget{
+			Children: []ui.Widget{
+				&ui.TextWidget{
+					String: results,
+					Color:  cs.Game.Theme().Menu,
+				},
+				cs.widget,
+			},
+			Spacing: 1,
+			HAlign:  ui.HAlignCenter,
+		},
+		Fill:          true,
+		PaddingTop:    1,
+		PaddingBottom: 1,
+		PaddingLeft:   2,
+		PaddingRight:  2,
+		Color:         cs.Game.Theme().MenuBox,
+	})
+}
@@ -0,0 +1,44 @@
+package game
+
+import (
+	"time"
+
+	"github.com/serhatsdev/sudoku/game/board"
+)
+
+// dailyDifficulty is the difficulty of the daily puzzles
+const dailyDifficulty = board.Medium
+
+// dateFormat is the format of the dates of the daily puzzles
+const dateFormat = "2006-01-02"
+
+func formatDate(date time.Time) string {
+	return date.Format(dateFormat)
+}
+
+// getDailyPuzzleID returns the id of the daily puzzle of the given UTC date,
+// the seed is the date so everyone gets the same puzzle on the same day
+func getDailyPuzzleID(date time.Time) board.PuzzleID {
+	seed := date.Year()*10000 + int(date.Month())*100 + date.Day()
+	return board.PuzzleID{
+		Size:       board.Classic.Size(),
+		Difficulty: dailyDifficulty,
+		Seed:       int64(seed),
+	}
+}
+
+// findDailySlot returns the save slot of the unfinished
+// daily puzzle of the given date if there is one
+func findDailySlot(date string) (SaveSlot, bool) {
+	slots, err := ListSaveSlots()
+	if err != nil {
+		return SaveSlot{}, false
+	}
+
+	for _, slot := range slots {
+		if slot.Daily == date && !slot.Completed {
+			return slot, true
+		}
+	}
+	return SaveSlot{}, false
+}
@@ -0,0 +1,62 @@
+package game
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/serhatsdev/sudoku/game/ui"
+)
+
+// dailyState shows the result of today's daily puzzle
+// and the daily streak with the option to play it
+type dailyState struct {
+	menuState
+	Today time.Time
+}
+
+func (ds *dailyState) Draw() {
+	stats := ds.Game.Statistics().Daily
+	date := formatDate(ds.Today)
+
+	result := "Not Solved"
+	if elapsed, solved := stats.SolveTime(date); solved {
+		result = formatDuration(elapsed)
+	}
+
+	ds.widget = &ui.MenuWidget{
+		Options:     getTitlesFromOptions(ds.Options),
+		CursorIndex: ds.Pos,
+		HAlign:      ui.HAlignCenter,
+		Color:       ds.Game.Theme().Menu,
+		Cursor:      ds.Game.Theme().MenuCursor,
+	}
+
+	ds.Game.Client().DrawCenter(&ui.BoxWidget{
+		Child: &ui.ColumnWidget{
+			Children: []ui.Widget{
+				&ui.TextWidget{
+					String: fmt.Sprintf("Daily %s", date),
+					Color:  ds.Game.Theme().MenuCursor,
+				},
+				&ui.StatusWidget{
+					Items: []ui.StatusItem{
+						{Label: "Level", Value: DifficultyName(dailyDifficulty)},
+						{Label: "Today", Value: result},
+						{Label: "Streak", Value: fmt.Sprint(stats.CurrentStreak(ds.Today))},
+						{Label: "Best Streak", Value: fmt.Sprint(stats.BestStreak)},
+					},
+					Color: ds.Game.Theme().Menu,
+				},
+				ds.widget,
+			},
+			Spacing: 1,
+			HAlign:  ui.HAlignCenter,
+		},
+		Fill:          true,
+		PaddingTop:    1,
+		PaddingBottom: 1,
+		PaddingLeft:   2,
+		PaddingRight:  2,
+		Color:         ds.Game.Theme().MenuBox,
+	})
+}
@@ -0,0 +1,52 @@
+package game
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/serhatsdev/sudoku/game/board"
+)
+
+var ErrUnknownDifficulty = errors.New("unknown difficulty")
+
+// difficulties are the difficulties players can choose in order
+var difficulties = []byte{
+	board.Beginner, board.Easy, board.Medium, board.Hard, board.VeryHard,
+}
+
+// difficultyNames are the names of the board difficulties
+var difficultyNames = map[byte]string{
+	board.Beginner: "Beginner",
+	board.Easy:     "Easy",
+	board.Medium:   "Medium",
+	board.Hard:     "Hard",
+	board.VeryHard: "Very Hard",
+}
+
+// isGenerated returns if the difficulty is one of the generated difficulties
+func isGenerated(difficulty byte) bool {
+	_, exist := difficultyNames[difficulty]
+	return exist
+}
+
+// DifficultyName returns the name of the difficulty,
+// "Custom" for boards that are not generated
+func DifficultyName(difficulty byte) string {
+	name, exist := difficultyNames[difficulty]
+	if !exist {
+		return "Custom"
+	}
+	return name
+}
+
+// ParseDifficulty returns the generated difficulty with the given name,
+// the case, spaces and dashes of the name are ignored
+func ParseDifficulty(name string) (byte, error) {
+	normalize := strings.NewReplacer(" ", "", "-", "")
+	for difficulty, cName := range difficultyNames {
+		if normalize.Replace(strings.ToLower(cName)) == normalize.Replace(strings.ToLower(name)) {
+			return difficulty, nil
+		}
+	}
+	return 0, ErrUnknownDifficulty
+}
@@ -0,0 +1,125 @@
+package export
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/serhatsdev/sudoku/game/board"
+)
+
+// ErrInvalidLayout is returned when puzzles can not be laid out
+// with the given number of puzzles per page
+var ErrInvalidLayout = errors.New("puzzles per page must be 1, 2, 4 or 6")
+
+// bookletLayouts are the columns and rows of the grids
+// on a page for the numbers of grids per page
+var bookletLayouts = map[int][2]int{
+	1: {1, 1},
+	2: {1, 2},
+	4: {2, 2},
+	6: {2, 3},
+}
+
+// bookletRules are the drawnRules the booklets can draw
+const bookletRules = board.JigsawRule
+
+// answersPerPage is the number of answer grids on a page
+const answersPerPage = 6
+
+// Sizes of the booklet pages in points
+const (
+	bookletMargin      = 50.0
+	bookletHeader      = 40.0
+	bookletLabelHeight = 20.0
+	bookletTitleSize   = 16.0
+	bookletLabelSize   = 11.0
+)
+
+// Booklet returns a PDF document of the puzzles laid out perPage
+// on each page, followed by the answers section with the correct
+// values of the puzzles. It returns ErrUnsupportedBoard for the
+// killer puzzles, the cages are not drawn.
+func Booklet(title string, puzzles []board.Board, perPage int) ([]byte, error) {
+	if _, exist := bookletLayouts[perPage]; !exist {
+		return nil, ErrInvalidLayout
+	}
+	for _, puzzle := range puzzles {
+		if puzzle.Rules()&drawnRules&^bookletRules != 0 {
+			return nil, ErrUnsupportedBoard
+		}
+	}
+
+	doc := &pdfDocument{}
+	addBookletPages(doc, title, "Puzzle", puzzles, perPage, false)
+	addBookletPages(doc, title+" - Answers", "Answer", puzzles, answersPerPage, true)
+
+	return doc.bytes(), nil
+}
+
+// addBookletPages adds the pages of the grids to the document
+func addBookletPages(doc *pdfDocument, title, label string, puzzles []board.Board, perPage int, answers bool) {
+	columns, rows := bookletLayouts[perPage][0], bookletLayouts[perPage][1]
+	slotWidth := (pdfPageWidth - bookletMargin*2) / float64(columns)
+	slotHeight := (pdfPageHeight - bookletMargin*2 - bookletHeader) / float64(rows)
+	size := math.Min(slotWidth, slotHeight-bookletLabelHeight) * 0.9
+
+	var page *pdfPage
+	for i, puzzle := range puzzles {
+		index := i % perPage
+		if index == 0 {
+			page = doc.addPage()
+			page.text(bookletMargin, pdfPageHeight-bookletMargin, bookletTitleSize, pdfBold, title)
+			page.text(pdfPageWidth/2-10, bookletMargin/2, bookletLabelSize, pdfRegular,
+				fmt.Sprintf("- %d -", len(doc.pages)))
+		}
+
+		slotX := bookletMargin + float64(index%columns)*slotWidth
+		slotTop := pdfPageHeight - bookletMargin - bookletHeader - float64(index/columns)*slotHeight
+		x := slotX + (slotWidth-size)/2
+		top := slotTop - bookletLabelHeight
+
+		page.text(x, top+6, bookletLabelSize, pdfBold, fmt.Sprintf("%s %d", label, i+1))
+		drawPDFGrid(page, x, top, size, puzzle, answers)
+	}
+}
+
+// drawPDFGrid draws the board with its top left corner at the given
+// position, the answers have the correct value in every cell
+func drawPDFGrid(page *pdfPage, x, top, size float64, b board.Board, answer bool) {
+	dims := b.Dimensions()
+	cellSize := size / float64(dims.Size())
+
+	// the heavy lines between the boxes are drawn over the light lines
+	for i := 0; i <= dims.Size(); i++ {
+		offset := float64(i) * cellSize
+		page.line(x+offset, top, x+offset, top-size, 0.5)
+		page.line(x, top-offset, x+size, top-offset, 0.5)
+	}
+	for _, line := range getHeavyLines(b) {
+		page.line(x+float64(line.x1)*cellSize, top-float64(line.y1)*cellSize,
+			x+float64(line.x2)*cellSize, top-float64(line.y2)*cellSize, 2)
+	}
+
+	fontSize := cellSize * 0.6
+	for i := 0; i < dims.Size(); i++ {
+		for j := 0; j < dims.Size(); j++ {
+			pos := board.Point2{X: j, Y: i}
+
+			value, font := 0, pdfBold
+			if b.IsPredefined(pos) {
+				value = b.GetCorrect(pos)
+			} else if answer {
+				value, font = b.GetCorrect(pos), pdfRegular
+			}
+			if value == 0 {
+				continue
+			}
+
+			// the digits of Helvetica are 0.556 of the font size wide
+			cellX := x + float64(j)*cellSize + cellSize/2 - fontSize*0.278
+			cellY := top - float64(i)*cellSize - cellSize/2 - fontSize*0.35
+			page.text(cellX, cellY, fontSize, font, string(board.Symbol(value)))
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package export
+
+import "github.com/serhatsdev/sudoku/game/board"
+
+// cageInset is the distance of the killer cage
+// outlines from the cell borders in cells
+const cageInset = 0.1
+
+// cageLine is a line of a cage outline
+// in cells from the top left corner of the board
+type cageLine struct {
+	x1, y1, x2, y2 float64
+}
+
+// directions are the up, right, down and left neighbours of a cell
+var directions = []board.Point2{{X: 0, Y: -1}, {X: 1, Y: 0}, {X: 0, Y: 1}, {X: -1, Y: 0}}
+
+// isInSameCage returns if the given cells are in the same killer cage
+func isInSameCage(b board.Board, a, c board.Point2) bool {
+	size := b.Dimensions().Size()
+	if c.X < 0 || c.Y < 0 || c.X >= size || c.Y >= size {
+		return false
+	}
+
+	cageA, existA := b.GetCage(a)
+	cageC, existC := b.GetCage(c)
+	return existA && existC && cageA.Cells[0] == cageC.Cells[0]
+}
+
+// getCageLines returns the outlines of the killer cages, the outlines are
+// cageInset inside the cells and go around the corners of the cages
+func getCageLines(b board.Board) []cageLine {
+	lines := []cageLine{}
+	for _, cage := range b.Cages() {
+		for _, pos := range cage.Cells {
+			for i, d := range directions {
+				if isInSameCage(b, pos, add(pos, d)) {
+					continue
+				}
+
+				// the line goes along the side from the previous
+				// direction to the next one
+				prev, next := directions[(i+3)%4], directions[(i+1)%4]
+				start := getCageLineEnd(b, pos, d, prev)
+				end := getCageLineEnd(b, pos, d, next)
+
+				cx, cy := float64(pos.X)+0.5, float64(pos.Y)+0.5
+				side := 0.5 - cageInset
+				lines = append(lines, cageLine{
+					cx + float64(d.X)*side + float64(prev.X)*start,
+					cy + float64(d.Y)*side + float64(prev.Y)*start,
+					cx + float64(d.X)*side + float64(next.X)*end,
+					cy + float64(d.Y)*side + float64(next.Y)*end,
+				})
+			}
+		}
+	}
+	return lines
+}
+
+// getCageLineEnd returns how far the outline of the side of the cell
+// in the given direction goes towards the given end direction
+func getCageLineEnd(b board.Board, pos, d, end board.Point2) float64 {
+	if !isInSameCage(b, pos, add(pos, end)) {
+		return 0.5 - cageInset
+	}
+	if isInSameCage(b, pos, add(add(pos, end), d)) {
+		return 0.5 + cageInset
+	}
+	return 0.5
+}
+
+// getCageSums returns the sums of the killer cages
+// by the top left cells of the cages
+func getCageSums(b board.Board) map[board.Point2]int {
+	sums := map[board.Point2]int{}
+	for _, cage := range b.Cages() {
+		topLeft := cage.Cells[0]
+		for _, pos := range cage.Cells {
+			if pos.Y < topLeft.Y || (pos.Y == topLeft.Y && pos.X < topLeft.X) {
+				topLeft = pos
+			}
+		}
+		sums[topLeft] = cage.Sum
+	}
+	return sums
+}
+
+func add(a, b board.Point2) board.Point2 {
+	return board.Point2{X: a.X + b.X, Y: a.Y + b.Y}
+}
@@ -0,0 +1,250 @@
+// Package export renders sudoku boards to text, Markdown, HTML and SVG
+package export
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/serhatsdev/sudoku/game/board"
+)
+
+// ErrUnknownFormat is returned when a format name is not known
+var ErrUnknownFormat = errors.New("unknown export format")
+
+// ErrUnsupportedBoard is returned when the format
+// can not show the regions or the cages of the board
+var ErrUnsupportedBoard = errors.New("the format can not show this board")
+
+// drawnRules are the rules the exported puzzles
+// can not be solved without, the formats draw them or refuse the boards
+const drawnRules = board.JigsawRule | board.KillerRule
+
+// Format is a format boards can be exported to
+type Format byte
+
+// Export formats
+const (
+	// Line is the line format with a character
+	// for every cell and '.' for the empty cells
+	Line = Format(iota)
+	// ASCII is a grid drawn with ASCII characters
+	ASCII
+	// Unicode is a grid drawn with box drawing characters
+	Unicode
+	// Markdown is a Markdown table
+	Markdown
+	// HTML is a standalone HTML page
+	HTML
+	// SVG is a standalone SVG image
+	SVG
+)
+
+// Options changes what is exported
+type Options struct {
+	// PuzzleOnly exports only the predefined values,
+	// the values and notes entered by the player are left out
+	PuzzleOnly bool
+	// Notes exports the notes of the empty cells,
+	// the line format has no place for notes
+	Notes bool
+}
+
+var formats = []struct {
+	name      string
+	title     string
+	extension string
+	// rules are the drawnRules the format can draw
+	rules  board.Rule
+	export func(b board.Board, options Options) string
+}{
+	Line:     {"line", "Line", ".txt", 0, exportLine},
+	ASCII:    {"ascii", "ASCII", ".txt", board.JigsawRule, exportASCII},
+	Unicode:  {"unicode", "Unicode", ".txt", board.JigsawRule, exportUnicode},
+	Markdown: {"markdown", "Markdown", ".md", 0, exportMarkdown},
+	HTML:     {"html", "HTML", ".html", drawnRules, exportHTML},
+	SVG:      {"svg", "SVG", ".svg", drawnRules, exportSVG},
+}
+
+// Formats returns all of the export formats
+func Formats() []Format {
+	all := []Format{}
+	for i := range formats {
+		all = append(all, Format(i))
+	}
+	return all
+}
+
+// ParseFormat returns the format with the given name
+func ParseFormat(name string) (Format, error) {
+	for i, format := range formats {
+		if format.name == strings.ToLower(name) {
+			return Format(i), nil
+		}
+	}
+	return 0, ErrUnknownFormat
+}
+
+// String returns the name of the format
+func (format Format) String() string {
+	return formats[format].name
+}
+
+// Title returns the display name of the format
+func (format Format) Title() string {
+	return formats[format].title
+}
+
+// Extension returns the file extension of the format
+func (format Format) Extension() string {
+	return formats[format].extension
+}
+
+// Supports returns if the format can show the given board
+func (format Format) Supports(b board.Board) bool {
+	return b.Rules()&drawnRules&^formats[format].rules == 0
+}
+
+// Export renders the board in the given format, it returns
+// ErrUnsupportedBoard if the format can not show the board
+func Export(b board.Board, format Format, options Options) (string, error) {
+	if !format.Supports(b) {
+		return "", ErrUnsupportedBoard
+	}
+	return formats[format].export(b, options), nil
+}
+
+// cell is a cell of the board as it is exported
+type cell struct {
+	value int
+	given bool
+	notes []int
+}
+
+// getCell returns the exported content of the cell at the given position
+func getCell(b board.Board, pos board.Point2, options Options) cell {
+	if b.IsPredefined(pos) {
+		return cell{value: b.Get(pos), given: true}
+	}
+	if options.PuzzleOnly {
+		return cell{}
+	}
+
+	c := cell{value: b.Get(pos)}
+	if c.value == 0 && options.Notes {
+		c.notes = b.GetNotes(pos)
+	}
+	return c
+}
+
+// getLineWeights returns the weights of the grid lines of the board, 1 for
+// the light lines and 2 for the heavy lines between the boxes and around
+// the board. The horizontal lines are above the cells of every row and the
+// vertical lines are at the left of the cells, the last lines are the
+// bottom and right borders. The boxes of the jigsaw boards are their regions.
+func getLineWeights(b board.Board) ([][]int, [][]int) {
+	size := b.Dimensions().Size()
+	horizontal, vertical := make([][]int, size+1), make([][]int, size)
+	for i := 0; i <= size; i++ {
+		horizontal[i] = make([]int, size)
+		for j := 0; j < size; j++ {
+			horizontal[i][j] = getLineWeight(b, board.Point2{X: j, Y: i - 1}, board.Point2{X: j, Y: i})
+		}
+	}
+	for i := 0; i < size; i++ {
+		vertical[i] = make([]int, size+1)
+		for j := 0; j <= size; j++ {
+			vertical[i][j] = getLineWeight(b, board.Point2{X: j - 1, Y: i}, board.Point2{X: j, Y: i})
+		}
+	}
+	return horizontal, vertical
+}
+
+// getLineWeight returns the weight of the line between the given cells,
+// one of them is outside of the board for the borders
+func getLineWeight(b board.Board, a, c board.Point2) int {
+	size := b.Dimensions().Size()
+	if a.X < 0 || a.Y < 0 || c.X >= size || c.Y >= size || b.GetRegion(a) != b.GetRegion(c) {
+		return 2
+	}
+	return 1
+}
+
+// lineSegment is a line between the given corners of the cells,
+// the top left corner of the board is 0, 0
+type lineSegment struct {
+	x1, y1, x2, y2 int
+}
+
+// getHeavyLines returns the heavy lines of the board, see getLineWeights,
+// the heavy lines next to each other are joined
+func getHeavyLines(b board.Board) []lineSegment {
+	size := b.Dimensions().Size()
+	horizontal, vertical := getLineWeights(b)
+	lines := []lineSegment{}
+	for i := 0; i <= size; i++ {
+		start := -1
+		for j := 0; j <= size; j++ {
+			heavy := j < size && horizontal[i][j] == 2
+			if heavy && start == -1 {
+				start = j
+			} else if !heavy && start != -1 {
+				lines = append(lines, lineSegment{start, i, j, i})
+				start = -1
+			}
+		}
+	}
+	for j := 0; j <= size; j++ {
+		start := -1
+		for i := 0; i <= size; i++ {
+			heavy := i < size && vertical[i][j] == 2
+			if heavy && start == -1 {
+				start = i
+			} else if !heavy && start != -1 {
+				lines = append(lines, lineSegment{j, start, j, i})
+				start = -1
+			}
+		}
+	}
+	return lines
+}
+
+// symbol returns the character of the value of the cell
+func (c cell) symbol() string {
+	return string(board.Symbol(c.value))
+}
+
+// joinSymbols returns the characters of the given values
+func joinSymbols(values []int, separator string) string {
+	symbols := []string{}
+	for _, value := range values {
+		symbols = append(symbols, string(board.Symbol(value)))
+	}
+	return strings.Join(symbols, separator)
+}
+
+// hasNote returns if the cell has the given note
+func (c cell) hasNote(note int) bool {
+	for _, cNote := range c.notes {
+		if cNote == note {
+			return true
+		}
+	}
+	return false
+}
+
+func exportLine(b board.Board, options Options) string {
+	builder := strings.Builder{}
+	size := b.Dimensions().Size()
+	for i := 0; i < size; i++ {
+		for j := 0; j < size; j++ {
+			c := getCell(b, board.Point2{X: j, Y: i}, options)
+			if c.value == 0 {
+				builder.WriteByte('.')
+			} else {
+				builder.WriteRune(board.Symbol(c.value))
+			}
+		}
+	}
+	builder.WriteByte('\n')
+	return builder.String()
+}
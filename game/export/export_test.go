@@ -0,0 +1,180 @@
+package export_test
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/serhatsdev/sudoku/game/board"
+	"github.com/serhatsdev/sudoku/game/export"
+)
+
+const puzzle = ".2..9.58.75.84.9328.912..4.4...5.216.763.2..55.2...87..6..341.82185.9..434...872."
+
+func getBoard(t *testing.T) board.Board {
+	grid, err := board.ParseLine(puzzle)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := board.NewFromGrid(grid)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b.Set(board.Point2{X: 0, Y: 0}, 6)
+	b.ToggleNote(board.Point2{X: 2, Y: 0}, 1)
+	return b
+}
+
+func TestExportLine(t *testing.T) {
+	b := getBoard(t)
+
+	tests := []struct {
+		name     string
+		options  export.Options
+		expected string
+	}{
+		{"entries", export.Options{}, "6" + puzzle[1:] + "\n"},
+		{"puzzle only", export.Options{PuzzleOnly: true}, puzzle + "\n"},
+	}
+
+	for _, test := range tests {
+		actual, err := export.Export(b, export.Line, test.options)
+		if err != nil || test.expected != actual {
+			t.Errorf("Export(%s) failed: Expected: %v, Actual:%v",
+				test.name, test.expected, actual)
+		}
+	}
+}
+
+func TestExportGrid(t *testing.T) {
+	b := getBoard(t)
+
+	tests := []struct {
+		format   export.Format
+		options  export.Options
+		expected string
+	}{
+		{export.ASCII, export.Options{}, "| 6 : 2 :   |   : 9 :   | 5 : 8 :   |"},
+		{export.Unicode, export.Options{}, "┃ 6 │ 2 │   ┃   │ 9 │   ┃ 5 │ 8 │   ┃"},
+		{export.Unicode, export.Options{Notes: true}, "┃     │     │1    ┃"},
+		{export.Markdown, export.Options{}, "| 6 | **2** |   |"},
+	}
+
+	for _, test := range tests {
+		actual, err := export.Export(b, test.format, test.options)
+		if err != nil || !strings.Contains(actual, test.expected) {
+			t.Errorf("Export(%s) failed: Expected to contain: %v, Actual:%v",
+				test.format, test.expected, actual)
+		}
+	}
+}
+
+func TestExportJigsaw(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	b, err := board.NewVariant(board.Classic, board.Medium, board.NoSymmetry, board.JigsawRule, random)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the first row has a heavy line at the borders and between the regions
+	expected := 2
+	for j := 1; j < board.Classic.Size(); j++ {
+		if b.GetRegion(board.Point2{X: j - 1, Y: 0}) != b.GetRegion(board.Point2{X: j, Y: 0}) {
+			expected++
+		}
+	}
+
+	data, err := export.Export(b, export.Unicode, export.Options{})
+	if err != nil {
+		t.Fatalf("Export(unicode) failed: %v", err)
+	}
+	if actual := strings.Count(strings.Split(data, "\n")[1], "┃"); actual != expected {
+		t.Errorf("Export(unicode) failed: Expected: %d heavy lines, Actual:%d", expected, actual)
+	}
+
+	for _, format := range []export.Format{export.Line, export.Markdown} {
+		if _, err := export.Export(b, format, export.Options{}); err != export.ErrUnsupportedBoard {
+			t.Errorf("Export(%s) failed: Expected: %v, Actual:%v", format, export.ErrUnsupportedBoard, err)
+		}
+	}
+}
+
+func TestExportKiller(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	b, err := board.NewVariant(board.Classic, board.Medium, board.NoSymmetry, board.KillerRule, random)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		format   export.Format
+		expected string
+	}{
+		{export.HTML, `<span class="sum">`},
+		{export.SVG, `font-size="9"`},
+	}
+
+	for _, test := range tests {
+		data, err := export.Export(b, test.format, export.Options{})
+		if err != nil {
+			t.Fatalf("Export(%s) failed: %v", test.format, err)
+		}
+		if actual := strings.Count(data, test.expected); actual != len(b.Cages()) {
+			t.Errorf("Export(%s) failed: Expected: %d cage sums, Actual:%d", test.format, len(b.Cages()), actual)
+		}
+	}
+
+	for _, format := range []export.Format{export.Line, export.ASCII, export.Unicode, export.Markdown} {
+		if _, err := export.Export(b, format, export.Options{}); err != export.ErrUnsupportedBoard {
+			t.Errorf("Export(%s) failed: Expected: %v, Actual:%v", format, export.ErrUnsupportedBoard, err)
+		}
+	}
+
+	if _, err := export.Booklet("Sudoku", []board.Board{b}, 1); err != export.ErrUnsupportedBoard {
+		t.Errorf("Booklet(killer) failed: Expected: %v, Actual:%v", export.ErrUnsupportedBoard, err)
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	for _, format := range export.Formats() {
+		actual, err := export.ParseFormat(format.String())
+		if err != nil || actual != format {
+			t.Errorf("ParseFormat(%s) failed: Expected: %v, Actual:%v", format, format, actual)
+		}
+	}
+
+	if _, err := export.ParseFormat("pdf"); err != export.ErrUnknownFormat {
+		t.Errorf("ParseFormat(pdf) failed: Expected: %v, Actual:%v", export.ErrUnknownFormat, err)
+	}
+}
+
+func TestBooklet(t *testing.T) {
+	puzzles := []board.Board{}
+	for i := 0; i < 5; i++ {
+		puzzles = append(puzzles, getBoard(t))
+	}
+
+	tests := []struct {
+		perPage  int
+		expected string
+	}{
+		{1, "/Count 6"},
+		{2, "/Count 4"},
+		{4, "/Count 3"},
+		{6, "/Count 2"},
+	}
+
+	for _, test := range tests {
+		data, err := export.Booklet("Sudoku", puzzles, test.perPage)
+		if err != nil || !strings.HasPrefix(string(data), "%PDF-") ||
+			!strings.Contains(string(data), test.expected) {
+			t.Errorf("Booklet(%d) failed: Expected: %v, Actual error:%v",
+				test.perPage, test.expected, err)
+		}
+	}
+
+	if _, err := export.Booklet("Sudoku", puzzles, 3); err != export.ErrInvalidLayout {
+		t.Errorf("Booklet(3) failed: Expected: %v, Actual:%v", export.ErrInvalidLayout, err)
+	}
+}
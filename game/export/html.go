@@ -0,0 +1,115 @@
+package export
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/serhatsdev/sudoku/game/board"
+)
+
+const htmlHeader = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Sudoku</title>
+<style>
+table { border-collapse: collapse; border: 3px solid #000; margin: 2em auto; }
+td { width: 2.5em; height: 2.5em; border: 1px solid #888; text-align: center;
+  font: 1.5em sans-serif; color: #2a5db0; padding: 0; position: relative; }
+td.given { color: #000; font-weight: bold; }
+td.right { border-right: 3px solid #000; }
+td.bottom { border-bottom: 3px solid #000; }
+.notes { display: grid; font-size: 0.4em; color: #6e7c8c; line-height: 1.6em; }
+.cage { position: absolute; top: 0; right: 0; bottom: 0; left: 0; border: 0 dashed #555; }
+.sum { position: absolute; top: 0.3em; left: 0.4em; font-size: 0.4em; line-height: 1em; color: #000; }
+</style>
+</head>
+<body>
+<table>
+`
+
+const htmlFooter = `</table>
+</body>
+</html>
+`
+
+// exportHTML renders the board as a table of a standalone page
+func exportHTML(b board.Board, options Options) string {
+	builder := strings.Builder{}
+	builder.WriteString(htmlHeader)
+
+	dims := b.Dimensions()
+	horizontal, vertical := getLineWeights(b)
+	sums := getCageSums(b)
+	for i := 0; i < dims.Size(); i++ {
+		builder.WriteString("<tr>")
+		for j := 0; j < dims.Size(); j++ {
+			c := getCell(b, board.Point2{X: j, Y: i}, options)
+
+			classes := []string{}
+			if c.given {
+				classes = append(classes, "given")
+			}
+			if vertical[i][j+1] == 2 && j != dims.Size()-1 {
+				classes = append(classes, "right")
+			}
+			if horizontal[i+1][j] == 2 && i != dims.Size()-1 {
+				classes = append(classes, "bottom")
+			}
+
+			pos := board.Point2{X: j, Y: i}
+			content := getHTMLContent(c, dims) + getHTMLCage(b, pos)
+			if sum, exist := sums[pos]; exist {
+				content += fmt.Sprintf(`<span class="sum">%d</span>`, sum)
+			}
+
+			builder.WriteString(fmt.Sprintf(`<td class="%s">%s</td>`,
+				strings.Join(classes, " "), content))
+		}
+		builder.WriteString("</tr>\n")
+	}
+
+	builder.WriteString(htmlFooter)
+	return builder.String()
+}
+
+// getHTMLCage returns the outline of the killer cage of the cell, the
+// sides next to the other cages are dashed and the other sides reach
+// the cell border so they meet the outline of the next cell
+func getHTMLCage(b board.Board, pos board.Point2) string {
+	if _, exist := b.GetCage(pos); !exist {
+		return ""
+	}
+
+	style := ""
+	for i, side := range []string{"top", "right", "bottom", "left"} {
+		if isInSameCage(b, pos, add(pos, directions[i])) {
+			style += fmt.Sprintf("%s: -1px; ", side)
+		} else {
+			style += fmt.Sprintf("%s: 3px; border-%s-width: 1px; ", side, side)
+		}
+	}
+	return fmt.Sprintf(`<div class="cage" style="%s"></div>`, strings.TrimSpace(style))
+}
+
+// getHTMLContent returns the content of the cell,
+// the notes are laid out in the shape of a box
+func getHTMLContent(c cell, dims board.Dimensions) string {
+	if c.value != 0 {
+		return c.symbol()
+	}
+	if len(c.notes) == 0 {
+		return ""
+	}
+
+	notes := ""
+	for note := 1; note <= dims.Size(); note++ {
+		if c.hasNote(note) {
+			notes += fmt.Sprintf("<span>%c</span>", board.Symbol(note))
+		} else {
+			notes += "<span></span>"
+		}
+	}
+	return fmt.Sprintf(`<div class="notes" style="grid-template-columns: repeat(%d, 1fr)">%s</div>`,
+		dims.BoxWidth, notes)
+}
@@ -0,0 +1,46 @@
+package export
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/serhatsdev/sudoku/game/board"
+)
+
+// exportMarkdown renders the board as a table, the predefined values
+// are bold and the notes are italic
+func exportMarkdown(b board.Board, options Options) string {
+	lines := []string{}
+
+	header := []string{}
+	separator := []string{}
+	size := b.Dimensions().Size()
+	for j := 1; j <= size; j++ {
+		header = append(header, fmt.Sprint(j))
+		separator = append(separator, ":-:")
+	}
+	lines = append(lines, markdownRow(header), markdownRow(separator))
+
+	for i := 0; i < size; i++ {
+		row := []string{}
+		for j := 0; j < size; j++ {
+			c := getCell(b, board.Point2{X: j, Y: i}, options)
+			if c.given {
+				row = append(row, "**"+c.symbol()+"**")
+			} else if c.value != 0 {
+				row = append(row, c.symbol())
+			} else if len(c.notes) != 0 {
+				row = append(row, "_"+joinSymbols(c.notes, "")+"_")
+			} else {
+				row = append(row, " ")
+			}
+		}
+		lines = append(lines, markdownRow(row))
+	}
+
+	return strings.Join(lines, "\n") + "\n"
+}
+
+func markdownRow(cells []string) string {
+	return "| " + strings.Join(cells, " | ") + " |"
+}
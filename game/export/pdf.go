@@ -0,0 +1,101 @@
+package export
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// Page size of the PDF documents, A4 in points
+const (
+	pdfPageWidth  = 595.0
+	pdfPageHeight = 842.0
+)
+
+// Fonts of the PDF documents, the standard fonts
+// are used so no font has to be embedded
+const (
+	pdfRegular = "F1"
+	pdfBold    = "F2"
+)
+
+// pdfDocument is a minimal PDF writer that draws lines and text
+type pdfDocument struct {
+	pages []*pdfPage
+}
+
+// pdfPage is a page of a PDF document, the origin is the bottom left corner
+type pdfPage struct {
+	content bytes.Buffer
+}
+
+// addPage adds a new page to the end of the document
+func (doc *pdfDocument) addPage() *pdfPage {
+	page := &pdfPage{}
+	// projecting line caps join the lines at the corners of the grids
+	page.content.WriteString("2 J\n")
+	doc.pages = append(doc.pages, page)
+	return page
+}
+
+// line draws a line with the given width
+func (page *pdfPage) line(x1, y1, x2, y2, width float64) {
+	fmt.Fprintf(&page.content, "%.2f w %.2f %.2f m %.2f %.2f l S\n", width, x1, y1, x2, y2)
+}
+
+// text draws the text with its baseline starting at the given position
+func (page *pdfPage) text(x, y, size float64, font, text string) {
+	fmt.Fprintf(&page.content, "BT /%s %.2f Tf %.2f %.2f Td (%s) Tj ET\n",
+		font, size, x, y, escapePDFString(text))
+}
+
+// bytes returns the encoded document
+func (doc *pdfDocument) bytes() []byte {
+	objects := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"", // the page tree is added after the page numbers are known
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica >>",
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold >>",
+	}
+
+	kids := []string{}
+	for _, page := range doc.pages {
+		pageNumber := len(objects) + 1
+		kids = append(kids, fmt.Sprintf("%d 0 R", pageNumber))
+
+		objects = append(objects,
+			fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.0f %.0f] "+
+				"/Resources << /Font << /%s 3 0 R /%s 4 0 R >> >> /Contents %d 0 R >>",
+				pdfPageWidth, pdfPageHeight, pdfRegular, pdfBold, pageNumber+1),
+			fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream",
+				page.content.Len(), page.content.String()),
+		)
+	}
+	objects[1] = fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>",
+		strings.Join(kids, " "), len(doc.pages))
+
+	buffer := bytes.Buffer{}
+	buffer.WriteString("%PDF-1.4\n")
+
+	offsets := []int{}
+	for i, object := range objects {
+		offsets = append(offsets, buffer.Len())
+		fmt.Fprintf(&buffer, "%d 0 obj\n%s\nendobj\n", i+1, object)
+	}
+
+	xref := buffer.Len()
+	fmt.Fprintf(&buffer, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&buffer, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&buffer, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n",
+		len(objects)+1, xref)
+
+	return buffer.Bytes()
+}
+
+// escapePDFString escapes the characters that end or escape PDF strings
+func escapePDFString(text string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, "(", `\(`, ")", `\)`)
+	return replacer.Replace(text)
+}
@@ -0,0 +1,94 @@
+package export
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/serhatsdev/sudoku/game/board"
+)
+
+// Sizes of the SVG image in pixels
+const (
+	svgCellSize = 40
+	svgMargin   = 10
+)
+
+// exportSVG renders the board as a standalone image
+func exportSVG(b board.Board, options Options) string {
+	dims := b.Dimensions()
+	svgSize := svgCellSize*dims.Size() + svgMargin*2
+
+	builder := strings.Builder{}
+	builder.WriteString(fmt.Sprintf(
+		`<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d">`+"\n",
+		svgSize, svgSize, svgSize, svgSize))
+	builder.WriteString(fmt.Sprintf(`<rect width="%d" height="%d" fill="#fff"/>`+"\n", svgSize, svgSize))
+
+	// the heavy lines between the boxes are drawn over the light lines
+	for i := 0; i <= dims.Size(); i++ {
+		builder.WriteString(getSVGLine(lineSegment{i, 0, i, dims.Size()}, 1))
+		builder.WriteString(getSVGLine(lineSegment{0, i, dims.Size(), i}, 1))
+	}
+	for _, line := range getHeavyLines(b) {
+		builder.WriteString(getSVGLine(line, 3))
+	}
+	for _, line := range getCageLines(b) {
+		builder.WriteString(fmt.Sprintf(
+			`<line x1="%.1f" y1="%.1f" x2="%.1f" y2="%.1f" stroke="#555" stroke-width="1" stroke-dasharray="4 3"/>`+"\n",
+			svgMargin+line.x1*svgCellSize, svgMargin+line.y1*svgCellSize,
+			svgMargin+line.x2*svgCellSize, svgMargin+line.y2*svgCellSize))
+	}
+
+	sums := getCageSums(b)
+	for i := 0; i < dims.Size(); i++ {
+		for j := 0; j < dims.Size(); j++ {
+			pos := board.Point2{X: j, Y: i}
+			c := getCell(b, pos, options)
+			x, y := svgMargin+j*svgCellSize, svgMargin+i*svgCellSize
+			builder.WriteString(getSVGContent(c, dims, x, y))
+
+			if sum, exist := sums[pos]; exist {
+				builder.WriteString(fmt.Sprintf(
+					`<text x="%d" y="%d" font-family="sans-serif" font-size="9" fill="#000">%d</text>`+"\n",
+					x+5, y+12, sum))
+			}
+		}
+	}
+
+	builder.WriteString("</svg>\n")
+	return builder.String()
+}
+
+// getSVGLine returns the line element of the given grid line
+func getSVGLine(line lineSegment, width int) string {
+	return fmt.Sprintf(
+		`<line x1="%d" y1="%d" x2="%d" y2="%d" stroke="#000" stroke-width="%d" stroke-linecap="square"/>`+"\n",
+		svgMargin+line.x1*svgCellSize, svgMargin+line.y1*svgCellSize,
+		svgMargin+line.x2*svgCellSize, svgMargin+line.y2*svgCellSize, width)
+}
+
+// getSVGContent returns the text elements of the cell with the given
+// top left corner, the notes are laid out in the shape of a box
+func getSVGContent(c cell, dims board.Dimensions, x, y int) string {
+	if c.value != 0 {
+		style := `fill="#2a5db0"`
+		if c.given {
+			style = `fill="#000" font-weight="bold"`
+		}
+
+		return fmt.Sprintf(
+			`<text x="%d" y="%d" font-family="sans-serif" font-size="24" text-anchor="middle" %s>%s</text>`+"\n",
+			x+svgCellSize/2, y+svgCellSize/2+8, style, c.symbol())
+	}
+
+	content := ""
+	noteWidth, noteHeight := svgCellSize/dims.BoxWidth, svgCellSize/dims.BoxHeight
+	for _, note := range c.notes {
+		noteX := x + (note-1)%dims.BoxWidth*noteWidth + noteWidth/2 + 1
+		noteY := y + (note-1)/dims.BoxWidth*noteHeight + noteHeight/2 + 4
+		content += fmt.Sprintf(
+			`<text x="%d" y="%d" font-family="sans-serif" font-size="10" text-anchor="middle" fill="#6e7c8c">%c</text>`+"\n",
+			noteX, noteY, board.Symbol(note))
+	}
+	return content
+}
@@ -0,0 +1,140 @@
+package export
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/serhatsdev/sudoku/game/board"
+)
+
+// gridStyle is the characters of a text grid, the lines are given
+// light and heavy. joins are the characters where the lines meet,
+// indexed by the weights of the up, right, down and left lines
+// in base 3, 0 is no line, 1 is a light and 2 is a heavy line.
+type gridStyle struct {
+	horizontal, vertical [2]rune
+	joins                []rune
+}
+
+var asciiStyle = gridStyle{
+	horizontal: [2]rune{'-', '='},
+	vertical:   [2]rune{':', '|'},
+	joins:      []rune(" " + strings.Repeat("+", 80)),
+}
+
+// unicodeStyle is the style of the board outline of the game
+var unicodeStyle = gridStyle{
+	horizontal: [2]rune{'─', '━'},
+	vertical:   [2]rune{'│', '┃'},
+	joins:      []rune(" ╴╸╷┐┑╻┒┓╶─╾┌┬┭┎┰┱╺╼━┍┮┯┏┲┳╵┘┙│┤┥╽┧┪└┴┵├┼┽┟╁╅┕┶┷┝┾┿┢╆╈╹┚┛╿┦┩┃┨┫┖┸┹┞╀╃┠╂╉┗┺┻┡╄╇┣╊╋"),
+}
+
+// join returns the character where the lines of the given weights meet
+func (style gridStyle) join(up, right, down, left int) rune {
+	return style.joins[up*27+right*9+down*3+left]
+}
+
+// cellWidth is the width of the cells of the text grids,
+// the cells with notes are larger, see getNotesCellSize
+const cellWidth = 3
+
+// getNotesCellSize returns the size of the cells that show
+// the notes in a grid with a note column for every column of a box
+func getNotesCellSize(dims board.Dimensions) (int, int) {
+	return dims.BoxWidth*2 - 1, dims.BoxHeight
+}
+
+func exportASCII(b board.Board, options Options) string {
+	return exportGrid(b, options, asciiStyle)
+}
+
+func exportUnicode(b board.Board, options Options) string {
+	return exportGrid(b, options, unicodeStyle)
+}
+
+func exportGrid(b board.Board, options Options, style gridStyle) string {
+	dims := b.Dimensions()
+	size := dims.Size()
+	width, height := cellWidth, 1
+	if options.Notes {
+		width, height = getNotesCellSize(dims)
+	}
+
+	horizontal, vertical := getLineWeights(b)
+
+	lines := []string{}
+	for i := 0; i <= size; i++ {
+		builder := strings.Builder{}
+		for j := 0; j <= size; j++ {
+			up, right, down, left := 0, 0, 0, 0
+			if i > 0 {
+				up = vertical[i-1][j]
+			}
+			if j < size {
+				right = horizontal[i][j]
+			}
+			if i < size {
+				down = vertical[i][j]
+			}
+			if j > 0 {
+				left = horizontal[i][j-1]
+			}
+			builder.WriteRune(style.join(up, right, down, left))
+			if j < size {
+				builder.WriteString(strings.Repeat(string(style.horizontal[right-1]), width))
+			}
+		}
+		lines = append(lines, builder.String())
+		if i == size {
+			break
+		}
+
+		cells := [][]string{}
+		for j := 0; j < size; j++ {
+			c := getCell(b, board.Point2{X: j, Y: i}, options)
+			cells = append(cells, getCellLines(c, width, height, dims.BoxWidth, options.Notes))
+		}
+		for k := 0; k < height; k++ {
+			builder := strings.Builder{}
+			for j := 0; j <= size; j++ {
+				builder.WriteRune(style.vertical[vertical[i][j]-1])
+				if j < size {
+					builder.WriteString(cells[j][k])
+					builder.WriteString(strings.Repeat(" ", width-len(cells[j][k])))
+				}
+			}
+			lines = append(lines, builder.String())
+		}
+	}
+
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// getCellLines returns the lines of a cell of the given size,
+// the notes are shown in rows of boxWidth notes if showNotes is set
+func getCellLines(c cell, width, height, boxWidth int, showNotes bool) []string {
+	lines := []string{}
+	for k := 0; k < height; k++ {
+		lines = append(lines, strings.Repeat(" ", width))
+	}
+
+	if c.value != 0 {
+		lines[height/2] = fmt.Sprintf("%*s%*s", width/2+1, c.symbol(), width/2, "")
+		return lines
+	}
+
+	if showNotes {
+		for k := 0; k < height; k++ {
+			notes := []string{}
+			for note := k*boxWidth + 1; note <= k*boxWidth+boxWidth; note++ {
+				if c.hasNote(note) {
+					notes = append(notes, string(board.Symbol(note)))
+				} else {
+					notes = append(notes, " ")
+				}
+			}
+			lines[k] = strings.Join(notes, " ")
+		}
+	}
+	return lines
+}
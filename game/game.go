@@ -1,6 +1,10 @@
 package game
 
 import (
+	"errors"
+	"fmt"
+	"time"
+
 	"github.com/serhatsdev/sudoku/game/board"
 	"github.com/serhatsdev/sudoku/game/theme"
 	"github.com/serhatsdev/sudoku/game/ui"
@@ -16,14 +20,77 @@ type Game interface {
 
 	// Board returns the current sudoku board
 	Board() board.Board
-	// SetBoard sets the current sudoku board
-	SetBoard(board board.Board)
+	// SetBoard saves the current game and starts a new one in a new save slot
+	// with the given board and difficulty, with a new move history,
+	// hint and mistake count and clock.
+	SetBoard(board board.Board, difficulty byte)
+	// StartPuzzle starts a new game with the generated puzzle of the given id,
+	// it returns an error if the rules of the id are not supported
+	StartPuzzle(id board.PuzzleID) error
+	// PuzzleID returns the id of the current puzzle,
+	// it is empty for the boards that are not generated
+	PuzzleID() string
+	// StartDaily starts a new game with the daily puzzle of the given date
+	StartDaily(date time.Time) error
+	// Daily returns the date of the current daily puzzle,
+	// it is empty if the current game is not a daily puzzle
+	Daily() string
+	// History returns the move history of the current board
+	History() *board.History
+
+	// HintCount returns the number of hints used for the current board
+	HintCount() int
+	// AddHint increases the number of hints used for the current board
+	AddHint()
+
+	// MistakeCount returns the number of wrong values
+	// placed to the current board
+	MistakeCount() int
+	// AddMistake increases the number of wrong values
+	// placed to the current board
+	AddMistake()
+
+	// Difficulty returns the difficulty of the current board
+	Difficulty() byte
+	// LastDifficulty returns the last difficulty chosen for a new game
+	LastDifficulty() byte
+
+	// IsCompleted returns if the current board is solved
+	IsCompleted() bool
+	// Complete marks the current board as solved and saves the game,
+	// so the finished board is not resumed on the next start
+	Complete()
+
+	// Save saves the current game to its save slot
+	Save() error
+	// SaveSlot returns the save slot of the current game
+	SaveSlot() string
+	// LoadSlot saves the current game and continues the game
+	// saved to the given slot
+	LoadSlot(slot string) error
+	// RenameSlot changes the name of the given save slot
+	RenameSlot(slot, name string) error
+	// DeleteSlot removes the given save slot, the game is recorded as
+	// abandoned if it is not completed. The current slot can not be deleted.
+	DeleteSlot(slot string) error
+
+	// Statistics returns the records of the played games
+	Statistics() Statistics
+
+	// Elapsed returns the play time of the current board,
+	// the time only passes while the play state is on top
+	Elapsed() time.Duration
 
 	// Theme returns the current theme
 	Theme() theme.Theme
 	// SetTheme sets current theme to the given theme
 	SetTheme(theme theme.Theme)
 
+	// Settings returns the current settings
+	Settings() Settings
+	// SetSettings sets and saves the current settings
+	SetSettings(settings Settings)
+
 	// MinWidth returns minimum terminal width
 	// required to run the game
 	MinWidth() int
@@ -47,34 +114,93 @@ type Game interface {
 	PopState() State
 }
 
+// autosaveInterval is the interval of the periodic saves,
+// the game is also saved after every move
+const autosaveInterval = 30 * time.Second
+
+var ErrCurrentSlot = errors.New("save slot is in use")
+
+// tryToLoadGame continues the most recently played game
 func tryToLoadGame(game *game) bool {
-	savedata, err := LoadSavedGame()
+	slots, err := ListSaveSlots()
+	if err != nil || len(slots) == 0 {
+		return false
+	}
+
+	savedata, err := LoadSavedGame(slots[0].ID)
 	if err != nil {
 		return false
 	}
 
-	game.board = savedata.Board
 	game.theme = savedata.Theme
+	game.lastDifficulty = savedata.LastDifficulty
+
+	// finished games are not resumed
+	if savedata.Completed {
+		return false
+	}
+
+	game.load(slots[0].ID, savedata)
 	return true
 }
 
-// NewGame returns a new game instance
-func NewGame(client ui.Client) (Game, error) {
+// load sets the current game to the given saved game
+func (game *game) load(slot string, savedata SaveData) {
+	game.slot = slot
+	game.slotName = savedata.Name
+	game.puzzleID = savedata.PuzzleID
+	game.daily = savedata.Daily
+	game.history = board.NewHistoryWithMoves(savedata.Board, savedata.Moves, savedata.MoveIndex)
+	game.difficulty = savedata.Difficulty
+	game.hintCount = savedata.HintCount
+	game.mistakeCount = savedata.MistakeCount
+	game.completed = savedata.Completed
+	game.clock.Reset(savedata.Elapsed)
+}
+
+// NewGame returns a new game instance, the game starts with the given
+// start function if it is not nil, otherwise the last game is continued
+// or a new puzzle is started
+func NewGame(client ui.Client, start func(Game) error) (Game, error) {
 	game := game{}
 	game.client = client
 	game.states = []State{}
 	game.minWidth = ui.BoardWidth
 	game.minHeight = ui.BoardHeight
+	game.lastDifficulty = board.Medium
+
+	settings, err := LoadSettings()
+	if err != nil {
+		settings = DefaultSettings()
+	}
+	game.settings = settings
+
+	statistics, err := LoadStatistics()
+	if err != nil {
+		statistics = Statistics{}
+	}
+	game.statistics = statistics
 
 	isSuccessful := tryToLoadGame(&game)
-	if !isSuccessful {
+	if game.theme.Name == "" {
 		themes, err := theme.GetThemes()
 		if err != nil {
 			return nil, err
 		}
 
 		game.theme = themes[0]
-		game.board = board.New(board.Medium)
+	}
+
+	if start != nil {
+		err := start(&game)
+		if err != nil {
+			return nil, err
+		}
+	} else if !isSuccessful {
+		err := game.StartPuzzle(board.NewPuzzleID(game.settings.Size, game.lastDifficulty, game.settings.Symmetry, game.settings.Rules))
+		if err != nil {
+			game.StartPuzzle(board.NewPuzzleID(game.settings.Size, game.lastDifficulty, game.settings.Symmetry, 0))
+		}
 	}
 
 	game.PushState(NewPlayState(&game))
@@ -82,10 +208,23 @@ func NewGame(client ui.Client) (Game, error) {
 }
 
 type game struct {
-	board  board.Board
-	states []State
-	client ui.Client
-	theme  theme.Theme
+	slot           string
+	slotName       string
+	puzzleID       string
+	daily          string
+	history        *board.History
+	difficulty     byte
+	lastDifficulty byte
+	hintCount      int
+	mistakeCount   int
+	completed      bool
+	clock          clock
+	states         []State
+	client         ui.Client
+	theme          theme.Theme
+	settings       Settings
+	statistics     Statistics
+	savedAt        time.Time
 
 	minWidth, minHeight int
 }
@@ -97,14 +236,31 @@ func (game *game) Start() error {
 		game.State().Draw()
 	})
 
+	game.client.OnTick(func() {
+		if time.Since(game.savedAt) >= autosaveInterval {
+			game.Save()
+		}
+
+		if game.clock.running {
+			game.client.Context().Clear()
+			game.State().Draw()
+		}
+	})
+
 	game.client.OnKeyPress(func(key string) {
 		if key == "ctrl+z" {
 			game.Exit()
 		}
 
-		game.client.Context().Clear()
-		game.State().OnKeyPress(key)
-		game.State().Draw()
+		game.update(func() {
+			game.State().OnKeyPress(key)
+		})
+	})
+
+	game.client.OnMouse(func(x, y int, button string) {
+		game.update(func() {
+			game.State().OnMouse(x, y, button)
+		})
 	})
 
 	err := game.client.Start()
@@ -115,21 +271,205 @@ func (game *game) Start() error {
 	return nil
 }
 
-func (game *game) Exit() {
-	SaveGame(SaveData{
-		Board: game.board,
-		Theme: game.theme,
-	})
+// update runs the given input handler of the current state and redraws
+// the screen, the game is saved if the handler moved on the board
+func (game *game) update(handle func()) {
+	history := game.history
+	_, index := history.Moves()
+
+	game.client.Context().Clear()
+	handle()
+	game.State().Draw()
+
+	// every move, undo and redo changes the move index
+	if _, newIndex := history.Moves(); history == game.history && newIndex != index {
+		game.Save()
+	}
+}
 
+func (game *game) Exit() {
+	game.Save()
 	game.client.Stop()
 }
 
+func (game *game) Save() error {
+	game.savedAt = time.Now()
+	moves, moveIndex := game.history.Moves()
+	return SaveGame(game.slot, SaveData{
+		Name:           game.slotName,
+		PuzzleID:       game.puzzleID,
+		Daily:          game.daily,
+		LastPlayed:     time.Now(),
+		Board:          game.history.Board,
+		Moves:          moves,
+		MoveIndex:      moveIndex,
+		Difficulty:     game.difficulty,
+		LastDifficulty: game.lastDifficulty,
+		HintCount:      game.hintCount,
+		MistakeCount:   game.mistakeCount,
+		Elapsed:        game.clock.Elapsed(),
+		Completed:      game.completed,
+		Theme:          game.theme,
+	})
+}
+
 func (game *game) Board() board.Board {
-	return game.board
+	return game.history
 }
 
-func (game *game) SetBoard(board board.Board) {
-	game.board = board
+func (game *game) SetBoard(b board.Board, difficulty byte) {
+	game.setBoard(b, difficulty, "", "")
+}
+
+func (game *game) StartPuzzle(id board.PuzzleID) error {
+	b, err := board.Generate(id)
+	if err != nil {
+		return err
+	}
+
+	game.setBoard(b, id.Difficulty, id.String(), "")
+	return nil
+}
+
+func (game *game) StartDaily(date time.Time) error {
+	id := getDailyPuzzleID(date)
+	b, err := board.Generate(id)
+	if err != nil {
+		return err
+	}
+
+	game.setBoard(b, id.Difficulty, id.String(), formatDate(date))
+	return nil
+}
+
+// setBoard starts a new game with the given board, the id of the puzzle
+// if it is generated and the date of the puzzle if it is a daily puzzle
+func (game *game) setBoard(b board.Board, difficulty byte, puzzleID, daily string) {
+	if game.history != nil {
+		game.Save()
+	}
+	game.statistics.RecordStart(difficulty)
+	SaveStatistics(game.statistics)
+
+	game.slot = NewSaveSlotID()
+	game.slotName = fmt.Sprintf("%s %s", DifficultyName(difficulty), time.Now().Format("Jan 2 15:04"))
+	if daily != "" {
+		game.slotName = "Daily " + daily
+	}
+	game.history = board.NewHistory(b)
+	game.puzzleID = puzzleID
+	game.daily = daily
+	game.difficulty = difficulty
+	if isGenerated(difficulty) {
+		game.lastDifficulty = difficulty
+	}
+	game.hintCount = 0
+	game.mistakeCount = 0
+	game.completed = false
+	game.clock.Reset(0)
+	game.Save()
+}
+
+func (game *game) PuzzleID() string {
+	return game.puzzleID
+}
+
+func (game *game) Daily() string {
+	return game.daily
+}
+
+func (game *game) History() *board.History {
+	return game.history
+}
+
+func (game *game) HintCount() int {
+	return game.hintCount
+}
+
+func (game *game) AddHint() {
+	game.hintCount++
+}
+
+func (game *game) MistakeCount() int {
+	return game.mistakeCount
+}
+
+func (game *game) AddMistake() {
+	game.mistakeCount++
+}
+
+func (game *game) Difficulty() byte {
+	return game.difficulty
+}
+
+func (game *game) LastDifficulty() byte {
+	return game.lastDifficulty
+}
+
+func (game *game) IsCompleted() bool {
+	return game.completed
+}
+
+func (game *game) Complete() {
+	game.completed = true
+	game.Save()
+
+	game.statistics.RecordWin(game.difficulty, game.clock.Elapsed())
+	if game.daily != "" {
+		game.statistics.RecordDaily(game.daily, game.clock.Elapsed())
+	}
+	SaveStatistics(game.statistics)
+}
+
+func (game *game) SaveSlot() string {
+	return game.slot
+}
+
+func (game *game) LoadSlot(slot string) error {
+	if slot == game.slot {
+		return nil
+	}
+
+	savedata, err := LoadSavedGame(slot)
+	if err != nil {
+		return err
+	}
+
+	game.Save()
+	game.load(slot, savedata)
+	game.updateClock()
+	return nil
+}
+
+func (game *game) RenameSlot(slot, name string) error {
+	if slot == game.slot {
+		game.slotName = name
+		return game.Save()
+	}
+
+	return RenameSaveSlot(slot, name)
+}
+
+func (game *game) DeleteSlot(slot string) error {
+	if slot == game.slot {
+		return ErrCurrentSlot
+	}
+
+	savedata, err := LoadSavedGame(slot)
+	if err == nil && !savedata.Completed {
+		game.statistics.RecordAbandon(savedata.Difficulty)
+		SaveStatistics(game.statistics)
+	}
+
+	return DeleteSaveSlot(slot)
+}
+
+func (game *game) Statistics() Statistics {
+	return game.statistics
+}
+
+func (game *game) Elapsed() time.Duration {
+	return game.clock.Elapsed()
 }
 
 func (game *game) Client() ui.Client {
@@ -144,28 +484,65 @@ func (game *game) SetTheme(theme theme.Theme) {
 	game.theme = theme
 }
 
+func (game *game) Settings() Settings {
+	return game.settings
+}
+
+func (game *game) SetSettings(settings Settings) {
+	game.settings = settings
+	SaveSettings(settings)
+}
+
 func (game *game) State() State {
 	return game.states[len(game.states)-1]
 }
 
 func (game *game) PushState(state State) {
 	game.states = append(game.states, state)
+	game.updateClock()
 }
 
 func (game *game) ChangeState(state State) {
 	game.states[len(game.states)-1] = state
+	game.updateClock()
 }
 
 func (game *game) PopState() State {
 	state := game.State()
 	game.states = game.states[:len(game.states)-1]
+	game.updateClock()
 	return state
 }
 
+// updateClock runs the clock only while playing,
+// the clock of a completed game stays paused
+func (game *game) updateClock() {
+	if len(game.states) == 0 || game.completed {
+		game.clock.Pause()
+		return
+	}
+
+	if _, playing := game.State().(*playState); playing {
+		game.clock.Resume()
+	} else {
+		game.clock.Pause()
+	}
+}
+
+// MinWidth returns the width of the compact board
+// if the current board is larger than the classic board
 func (game *game) MinWidth() int {
+	width, _ := ui.GetBoardSize(game.Board().Dimensions(), false)
+	if width > game.minWidth {
+		return width
+	}
 	return game.minWidth
 }
 
 func (game *game) MinHeight() int {
+	_, height := ui.GetBoardSize(game.Board().Dimensions(), false)
+	if height > game.minHeight {
+		return height
+	}
 	return game.minHeight
 }
@@ -0,0 +1,134 @@
+package game
+
+import (
+	"strings"
+
+	"github.com/serhatsdev/sudoku/game/board"
+)
+
+// Hint stages, each hint request moves the hint to the next stage
+const (
+	hintStageHighlight = iota + 1
+	hintStageTechnique
+	hintStagePlace
+)
+
+// hint is the next value the player can find with logic
+type hint struct {
+	// board is the board the hint is found for
+	board board.Board
+	// techniques are the techniques needed to find the placement in order
+	techniques []board.Technique
+	// highlights are the cells of the rows, columns and boxes
+	// the placement is based on
+	highlights map[board.Point2]struct{}
+	placement  board.Candidate
+	stage      int
+}
+
+// findHint returns a hint for the given board. Wrong values are ignored,
+// if the logic solver can not find a placement the correct value of
+// the given position (or the first unsolved cell) is revealed. The
+// techniques do not search the cages, diagonals and the other units of
+// the variant rules, so the hints of the variants often reveal a cell.
+// It returns nil if the board is solved.
+func findHint(b board.Board, pos board.Point2) *hint {
+	grid := board.NewGrid(b.Dimensions().Size())
+	for i := range grid {
+		for j := range grid[i] {
+			cPos := board.Point2{X: j, Y: i}
+			if b.IsCorrect(cPos) {
+				grid[i][j] = b.Get(cPos)
+			}
+		}
+	}
+
+	ls := board.NewLogicSolver(grid, b.Constraints()...)
+	for {
+		steps, found := ls.NextPlacement()
+		if !found {
+			break
+		}
+
+		last := steps[len(steps)-1]
+		for _, placement := range last.Placements {
+			if b.IsCorrect(placement.Pos) {
+				continue
+			}
+
+			h := &hint{
+				board:      b,
+				highlights: map[board.Point2]struct{}{},
+				placement:  placement,
+			}
+			for _, step := range steps {
+				if !containsTechnique(h.techniques, step.Technique) {
+					h.techniques = append(h.techniques, step.Technique)
+				}
+			}
+			for _, unit := range last.Units {
+				for _, cPos := range ls.Positions(unit) {
+					h.highlights[cPos] = struct{}{}
+				}
+			}
+
+			return h
+		}
+	}
+
+	if b.IsCorrect(pos) {
+		unsolved, exist := findUnsolvedCell(b)
+		if !exist {
+			return nil
+		}
+		pos = unsolved
+	}
+
+	return &hint{
+		board:      b,
+		highlights: map[board.Point2]struct{}{pos: {}},
+		placement:  board.Candidate{Pos: pos, Value: b.GetCorrect(pos)},
+	}
+}
+
+// message returns the text shown for the current stage
+func (h *hint) message() string {
+	if h.stage == hintStageHighlight {
+		return "Hint: look at the highlighted cells"
+	}
+
+	if len(h.techniques) == 0 && h.board.Rules()&^board.JigsawRule != 0 {
+		return "Hint: no logical step without the variant rules, revealing a cell"
+	}
+	if len(h.techniques) == 0 {
+		return "Hint: no logical step, revealing a cell"
+	}
+
+	names := []string{}
+	for _, technique := range h.techniques {
+		names = append(names, technique.String())
+	}
+	return "Hint: " + strings.Join(names, ", ")
+}
+
+func findUnsolvedCell(b board.Board) (board.Point2, bool) {
+	size := b.Dimensions().Size()
+	for i := 0; i < size; i++ {
+		for j := 0; j < size; j++ {
+			pos := board.Point2{X: j, Y: i}
+			if !b.IsCorrect(pos) {
+				return pos, true
+			}
+		}
+	}
+	return board.Point2{}, false
+}
+
+func containsTechnique(techniques []board.Technique, technique board.Technique) bool {
+	for _, cTechnique := range techniques {
+		if cTechnique == technique {
+			return true
+		}
+	}
+	return false
+}
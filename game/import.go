@@ -0,0 +1,45 @@
+package game
+
+import (
+	"os"
+	"path"
+	"strings"
+
+	"github.com/serhatsdev/sudoku/game/board"
+)
+
+// ParsePuzzle returns a board of the puzzle text given
+// in one of the formats board.Parse supports
+func ParsePuzzle(text string) (board.Board, error) {
+	grid, err := board.Parse(text)
+	if err != nil {
+		return nil, err
+	}
+
+	return board.NewFromGrid(grid)
+}
+
+// ImportPuzzle returns a board of the puzzle in the given file,
+// .sdk and .ss files are parsed in their formats
+// and other files in any of the supported formats
+func ImportPuzzle(file string) (board.Board, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	parse := board.Parse
+	switch strings.ToLower(path.Ext(file)) {
+	case ".sdk":
+		parse = board.ParseSDK
+	case ".ss":
+		parse = board.ParseSS
+	}
+
+	grid, err := parse(string(data))
+	if err != nil {
+		return nil, err
+	}
+
+	return board.NewFromGrid(grid)
+}
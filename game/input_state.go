@@ -0,0 +1,107 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/serhatsdev/sudoku/game/ui"
+)
+
+// inputWidth is the number of characters shown of the text,
+// the end of longer texts is shown
+const inputWidth = 24
+
+// inputState reads a line of text,
+// enter submits the text and esc cancels
+type inputState struct {
+	Game      Game
+	Title     string
+	Value     string
+	MaxLength int
+	OnSubmit  func(value string) error
+	// Error is the error of the last submit
+	Error string
+}
+
+func (is *inputState) OnResize(width, height int) {
+	if width < is.Game.MinWidth() || height < is.Game.MinHeight() {
+		is.Game.PushState(NewSmallSizeState(is.Game, width, height))
+	}
+}
+
+func (is *inputState) OnKeyPress(key string) {
+	length := utf8.RuneCountInString(is.Value)
+
+	if key == "esc" {
+		is.Game.PopState()
+	} else if key == "enter" {
+		value := strings.TrimSpace(is.Value)
+		if value == "" {
+			return
+		}
+
+		err := is.OnSubmit(value)
+		if err != nil {
+			is.Error = err.Error()
+		}
+	} else if key == "backspace" && length > 0 {
+		is.Value = string([]rune(is.Value)[:length-1])
+		is.Error = ""
+	} else if utf8.RuneCountInString(key) == 1 && length < is.MaxLength {
+		is.Value += key
+		is.Error = ""
+	}
+}
+
+func (is *inputState) OnMouse(x, y int, button string) {}
+
+func (is *inputState) Draw() {
+	text := []rune(is.Value + "_")
+	if len(text) > inputWidth {
+		text = text[len(text)-inputWidth:]
+	}
+
+	children := []ui.Widget{
+		&ui.TextWidget{
+			String: is.Title,
+			Color:  is.Game.Theme().Menu,
+		},
+		&ui.TextWidget{
+			String: fmt.Sprintf("%-*s", inputWidth, string(text)),
+			Color:  is.Game.Theme().MenuCursor,
+		},
+	}
+	if is.Error != "" {
+		children = append(children, &ui.TextWidget{
+			String: is.Error,
+			Color:  is.Game.Theme().Menu,
+		})
+	}
+
+	is.Game.Client().DrawCenter(&ui.BoxWidget{
+		Child: &ui.ColumnWidget{
+			Children: children,
+			Spacing:  1,
+			HAlign:   ui.HAlignCenter,
+		},
+		Fill:          true,
+		PaddingTop:    1,
+		PaddingBottom: 1,
+		PaddingLeft:   2,
+		PaddingRight:  2,
+		Color:         is.Game.Theme().MenuBox,
+	})
+}
+
+// withoutPath returns the cause of file errors,
+// the path is already shown in the input
+func withoutPath(err error) error {
+	var pathErr *fs.PathError
+	if errors.As(err, &pathErr) {
+		return pathErr.Err
+	}
+	return err
+}
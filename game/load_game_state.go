@@ -0,0 +1,86 @@
+package game
+
+import (
+	"fmt"
+
+	"github.com/serhatsdev/sudoku/game/ui"
+)
+
+// maxVisibleSlots is the number of save slots listed at once
+const maxVisibleSlots = 7
+
+// loadGameState lists the save slots with the details of the chosen slot
+type loadGameState struct {
+	menuState
+	Slots []SaveSlot
+}
+
+// selectSlot moves the cursor to the given slot if it exists
+func (ls *loadGameState) selectSlot(slot string) {
+	for i, cSlot := range ls.Slots {
+		if cSlot.ID == slot {
+			ls.Pos = i
+			return
+		}
+	}
+}
+
+func (ls *loadGameState) Draw() {
+	if len(ls.Slots) == 0 {
+		ls.menuState.Draw()
+		return
+	}
+
+	slot := ls.Slots[ls.Pos]
+	progress := fmt.Sprintf("%d%%", slot.Progress)
+	if slot.Completed {
+		progress = "Solved"
+	}
+	// saves of the older versions have no play date
+	lastPlayed := "-"
+	if !slot.LastPlayed.IsZero() {
+		lastPlayed = slot.LastPlayed.Format("2006-01-02 15:04")
+	}
+
+	ls.widget = &ui.MenuWidget{
+		Options:     getTitlesFromOptions(ls.Options),
+		CursorIndex: ls.Pos,
+		HAlign:      ui.HAlignCenter,
+		MaxHeight:   maxVisibleSlots,
+		Color:       ls.Game.Theme().Menu,
+		Cursor:      ls.Game.Theme().MenuCursor,
+	}
+
+	ls.Game.Client().DrawCenter(&ui.BoxWidget{
+		Child: &ui.ColumnWidget{
+			Children: []ui.Widget{
+				ls.widget,
+				&ui.StatusWidget{
+					Items: []ui.StatusItem{
+						{Label: "Level", Value: DifficultyName(slot.Difficulty)},
+						{Label: "Progress", Value: progress},
+						{Label: "Time", Value: formatDuration(slot.Elapsed)},
+						{Label: "Last Played", Value: lastPlayed},
+					},
+					Color: ls.Game.Theme().Menu,
+				},
+			},
+			Spacing: 1,
+			HAlign:  ui.HAlignCenter,
+		},
+		Fill:          true,
+		PaddingTop:    1,
+		PaddingBottom: 1,
+		PaddingLeft:   2,
+		PaddingRight:  2,
+		Color:         ls.Game.Theme().MenuBox,
+	})
+}
+
+// reloadLoadGameMenu replaces the current state with
+// a new load game menu that has the given slot chosen
+func reloadLoadGameMenu(game Game, slot string) {
+	state := NewLoadGameMenuState(game).(*loadGameState)
+	state.selectSlot(slot)
+	game.ChangeState(state)
+}
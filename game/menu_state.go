@@ -1,6 +1,9 @@
 package game
 
 import (
+	"fmt"
+
+	"github.com/serhatsdev/sudoku/game/board"
 	"github.com/serhatsdev/sudoku/game/ui"
 )
 
@@ -13,6 +16,8 @@ type menuState struct {
 	Game    Game
 	Pos     int
 	Options []menuOption
+	// widget is the last drawn menu, the clicked options are found on it
+	widget *ui.MenuWidget
 }
 
 func (ms *menuState) OnResize(width, height int) {
@@ -36,15 +41,31 @@ func (ms *menuState) OnKeyPress(key string) {
 	}
 }
 
+// OnMouse chooses the clicked option, the wheel moves the cursor
+func (ms *menuState) OnMouse(x, y int, button string) {
+	if button == "wheel_up" {
+		ms.OnKeyPress("arrow_up")
+	} else if button == "wheel_down" {
+		ms.OnKeyPress("arrow_down")
+	} else if button == "left" && ms.widget != nil {
+		if index, found := ms.widget.OptionAt(x, y); found {
+			ms.Pos = index
+			ms.Options[ms.Pos].function()
+		}
+	}
+}
+
 func (ms *menuState) Draw() {
+	ms.widget = &ui.MenuWidget{
+		Options:     getTitlesFromOptions(ms.Options),
+		CursorIndex: ms.Pos,
+		HAlign:      ui.HAlignCenter,
+		Color:       ms.Game.Theme().Menu,
+		Cursor:      ms.Game.Theme().MenuCursor,
+	}
+
 	ms.Game.Client().DrawCenter(&ui.BoxWidget{
-		Child: &ui.MenuWidget{
-			Options:     getTitlesFromOptions(ms.Options),
-			CursorIndex: ms.Pos,
-			HAlign:      ui.HAlignCenter,
-			Color:       ms.Game.Theme().Menu,
-			Cursor:      ms.Game.Theme().MenuCursor,
-		},
+		Child:         ms.widget,
 		PaddingTop:    1,
 		PaddingBottom: 1,
 		PaddingLeft:   1,
@@ -60,3 +81,83 @@ func getTitlesFromOptions(options []menuOption) []string {
 	}
 	return titles
 }
+
+func getSymmetryTitle(symmetry board.Symmetry) string {
+	return "Symmetry: " + symmetry.String()
+}
+
+// getNextSymmetry returns the symmetry after the given one in menu order
+func getNextSymmetry(symmetry board.Symmetry) board.Symmetry {
+	for i, cSymmetry := range board.Symmetries {
+		if cSymmetry == symmetry {
+			return board.Symmetries[(i+1)%len(board.Symmetries)]
+		}
+	}
+	return board.NoSymmetry
+}
+
+func getSizeTitle(size int) string {
+	return fmt.Sprintf("Size: %dx%d", size, size)
+}
+
+// getNextSize returns the board size after the given one
+func getNextSize(size int) int {
+	for i, dims := range board.Sizes {
+		if dims.Size() == size {
+			return board.Sizes[(i+1)%len(board.Sizes)].Size()
+		}
+	}
+	return board.Classic.Size()
+}
+
+// getRulesTitle returns the title of the rules option,
+// the rules the size can not have are marked as unsupported
+func getRulesTitle(size int, rules board.Rule) string {
+	if !supportsRules(size, rules) {
+		return "Unsupported: " + getRulesName(rules)
+	}
+	return "Rules: " + getRulesName(rules)
+}
+
+// supportsRules returns if the new games of the given size can have the rules
+func supportsRules(size int, rules board.Rule) bool {
+	dims, err := board.DimensionsOf(size)
+	return err == nil && board.SupportsRules(dims, rules)
+}
+
+// getRulesName returns the name of the first rule and
+// the number of the other rules, so the title fits the menu
+func getRulesName(rules board.Rule) string {
+	count := 0
+	first := board.Rule(0)
+	for _, rule := range board.Rules {
+		if rules.Has(rule) {
+			if count == 0 {
+				first = rule
+			}
+			count++
+		}
+	}
+
+	if count > 1 {
+		return fmt.Sprintf("%s +%d", first, count-1)
+	}
+	return rules.String()
+}
+
+func getToggleTitle(title string, value bool) string {
+	if value {
+		return title + ": On"
+	}
+	return title + ": Off"
+}
+
+// returnToPlayState pops the states on top of the play state
+func returnToPlayState(game Game) {
+	for {
+		if _, playing := game.State().(*playState); playing {
+			return
+		}
+		game.PopState()
+	}
+}
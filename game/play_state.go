@@ -1,15 +1,38 @@
 package game
 
 import (
-	"strconv"
+	"fmt"
+	"strings"
 
 	"github.com/serhatsdev/sudoku/game/board"
 	"github.com/serhatsdev/sudoku/game/ui"
 )
 
+// statusMargin is the space between the board and the status
+const statusMargin = 2
+
 type playState struct {
 	Game Game
 	Pos  board.Point2
+	// NoteMode makes number keys toggle notes instead of values
+	NoteMode bool
+	// Hint is the currently shown hint
+	Hint *hint
+	// widget is the last drawn board, the clicked cells are found on it
+	widget *ui.BoardWidget
+	// cycled is the cell last changed by the wheel,
+	// the next wheel steps on it are added to the same move
+	cycled *cycledCell
+}
+
+// cycledCell is a cell of a board, the number of applied moves and
+// the move after the wheel changed it and the note the wheel set
+type cycledCell struct {
+	board board.Board
+	pos   board.Point2
+	index int
+	move  board.Move
+	note  int
 }
 
 func (ps *playState) OnResize(width, height int) {
@@ -19,35 +42,301 @@ func (ps *playState) OnResize(width, height int) {
 }
 
 func (ps *playState) OnKeyPress(key string) {
+	ps.dropStaleHint()
+	ps.keepCursorOnBoard()
+
 	if key == "esc" {
 		ps.Game.PushState(NewMenuState(ps.Game))
 		return
 	}
 
+	if key == "h" || key == "H" {
+		ps.showHint()
+		return
+	}
+
+	// the hint is kept while moving the cursor
+	if !strings.HasPrefix(key, "arrow_") {
+		ps.Hint = nil
+	}
+
+	// the letters of the values of the large boards
+	// take the place of the shortcuts
+	size := ps.Game.Board().Dimensions().Size()
+	value := getKeyValue(key, size)
+
 	if key == "arrow_up" && ps.Pos.Y > 0 {
 		ps.Pos.Y--
-	} else if key == "arrow_down" && ps.Pos.Y < 8 {
+	} else if key == "arrow_down" && ps.Pos.Y < size-1 {
 		ps.Pos.Y++
 	} else if key == "arrow_left" && ps.Pos.X > 0 {
 		ps.Pos.X--
-	} else if key == "arrow_right" && ps.Pos.X < 8 {
+	} else if key == "arrow_right" && ps.Pos.X < size-1 {
 		ps.Pos.X++
-	} else {
-		if key == "e" || key == "E" {
-			ps.Game.Board().Set(ps.Pos, 0)
+	} else if value != 0 {
+		if ps.NoteMode {
+			ps.Game.Board().ToggleNote(ps.Pos, value)
 		} else {
-			num, err := strconv.Atoi(key)
-			if err == nil && num > 0 && num < 10 {
-				ps.Game.Board().Set(ps.Pos, num)
-			}
+			ps.placeValue(value)
+		}
+	} else if key == "n" || key == "N" {
+		ps.NoteMode = !ps.NoteMode
+	} else if key == "u" || key == "U" {
+		ps.Game.History().Undo()
+	} else if key == "r" || key == "R" {
+		ps.Game.History().Redo()
+	} else if key == "e" || key == "E" || key == "backspace" || key == "delete" {
+		if ps.NoteMode {
+			ps.Game.Board().ClearNotes(ps.Pos)
+		} else {
+			ps.Game.Board().Set(ps.Pos, 0)
+		}
+	}
+
+	ps.checkCompletion()
+}
+
+// OnMouse selects the clicked cell, the wheel selects the cell
+// under the mouse and cycles its value or its note in note mode
+func (ps *playState) OnMouse(x, y int, button string) {
+	ps.dropStaleHint()
+	ps.keepCursorOnBoard()
+	if ps.widget == nil {
+		return
+	}
+
+	pos, onBoard := ps.widget.ScreenToGrid(x, y)
+	if !onBoard {
+		return
+	}
+
+	// the hint is kept while moving the cursor
+	if button == "left" {
+		ps.Pos = pos
+	} else if button == "wheel_up" {
+		ps.Pos = pos
+		ps.cycleValue(1)
+	} else if button == "wheel_down" {
+		ps.Pos = pos
+		ps.cycleValue(-1)
+	}
+}
+
+// cycleValue changes the value of the current cell by the given step,
+// the empty cell comes after the last value and before the first one.
+// The values passed are not counted as mistakes and do not remove notes,
+// the steps on the same cell are recorded as a single move.
+func (ps *playState) cycleValue(step int) {
+	if ps.Game.Board().IsPredefined(ps.Pos) {
+		return
+	}
+
+	ps.Hint = nil
+	history := ps.Game.History()
+	_, index := history.Moves()
+	note := 0
+	cycled := ps.cycled
+	// a move made after an undo can have the same index, it is not reopened
+	reopened := cycled != nil && cycled.board == ps.Game.Board() && cycled.pos == ps.Pos &&
+		cycled.index == index && history.IsLastMove(cycled.move)
+	if reopened {
+		history.ReopenGroup()
+		note = cycled.note
+	} else {
+		history.BeginGroup()
+	}
+
+	if ps.NoteMode {
+		note = ps.cycleNote(note, step)
+	} else {
+		size := history.Dimensions().Size()
+		history.Set(ps.Pos, (history.Get(ps.Pos)+step+size+1)%(size+1))
+	}
+	history.EndGroup()
+
+	// a step that changed nothing did not record a move to add to
+	if _, newIndex := history.Moves(); reopened || newIndex != index {
+		ps.cycled = &cycledCell{ps.Game.Board(), ps.Pos, newIndex, history.LastMove(), note}
+	} else {
+		ps.cycled = nil
+	}
+	ps.checkCompletion()
+}
+
+// cycleNote replaces the given note of the current cell with the next
+// value by the given step that is not a note yet and returns it, the
+// other notes are kept. No note comes after the last value and before
+// the first one, the given note is 0 when the cycle starts.
+func (ps *playState) cycleNote(note, step int) int {
+	b := ps.Game.Board()
+	size := b.Dimensions().Size()
+	next := (note + step + size + 1) % (size + 1)
+	for next != 0 && hasNote(b, ps.Pos, next) {
+		next = (next + step + size + 1) % (size + 1)
+	}
+
+	if note != 0 {
+		b.ToggleNote(ps.Pos, note)
+	}
+	if next != 0 {
+		b.ToggleNote(ps.Pos, next)
+	}
+	return next
+}
+
+// getKeyValue returns the value of the symbol key
+// on a board of the given size, or 0 if it is not a value
+func getKeyValue(key string, size int) int {
+	runes := []rune(key)
+	if len(runes) != 1 {
+		return 0
+	}
+
+	value := board.SymbolValue(runes[0])
+	if value > size {
+		return 0
+	}
+	return value
+}
+
+// keepCursorOnBoard moves the cursor to the center
+// if the board is replaced by a smaller one
+func (ps *playState) keepCursorOnBoard() {
+	size := ps.Game.Board().Dimensions().Size()
+	if ps.Pos.X >= size || ps.Pos.Y >= size {
+		ps.Pos = board.Point2{X: size / 2, Y: size / 2}
+	}
+}
+
+// showHint moves the current hint to the next stage,
+// a new hint is found if there is no current hint
+func (ps *playState) showHint() {
+	if ps.Hint == nil {
+		ps.Hint = findHint(ps.Game.Board(), ps.Pos)
+		if ps.Hint == nil {
+			return
+		}
+		ps.Game.AddHint()
+	}
+
+	ps.Hint.stage++
+	if ps.Hint.stage == hintStagePlace {
+		ps.Pos = ps.Hint.placement.Pos
+		ps.placeValue(ps.Hint.placement.Value)
+		ps.Hint = nil
+		ps.checkCompletion()
+	}
+}
+
+// checkCompletion shows the results when the board is solved
+func (ps *playState) checkCompletion() {
+	if !ps.Game.IsCompleted() && ps.Game.Board().IsSolved() {
+		ps.Game.Complete()
+		ps.Game.PushState(NewCompleteState(ps.Game))
+	}
+}
+
+// placeValue sets the value of the current cell and removes it
+// from the notes of the peers if the setting is enabled,
+// all changes are recorded as a single move
+func (ps *playState) placeValue(value int) {
+	if ps.Game.Board().IsPredefined(ps.Pos) {
+		return
+	}
+
+	ps.Game.History().BeginGroup()
+	defer ps.Game.History().EndGroup()
+
+	ps.Game.Board().Set(ps.Pos, value)
+	if !ps.Game.Board().IsCorrect(ps.Pos) {
+		ps.Game.AddMistake()
+	}
+
+	if !ps.Game.Settings().AutoRemoveNotes {
+		return
+	}
+
+	for peer := range ps.Game.Board().GetPeers(ps.Pos) {
+		if hasNote(ps.Game.Board(), peer, value) {
+			ps.Game.Board().ToggleNote(peer, value)
+		}
+	}
+}
+
+func hasNote(b board.Board, pos board.Point2, value int) bool {
+	for _, note := range b.GetNotes(pos) {
+		if note == value {
+			return true
 		}
 	}
+	return false
+}
+
+// dropStaleHint removes the hint if the board is
+// changed by starting or loading another game
+func (ps *playState) dropStaleHint() {
+	if ps.Hint != nil && ps.Hint.board != ps.Game.Board() {
+		ps.Hint = nil
+	}
 }
 
 func (ps *playState) Draw() {
-	ps.Game.Client().DrawCenter(&ui.BoardWidget{
+	ps.dropStaleHint()
+	ps.keepCursorOnBoard()
+	width, height := ps.Game.Client().Size()
+
+	boardWidget := &ui.BoardWidget{
 		Board:     ps.Game.Board(),
 		CursorPos: ps.Pos,
 		Theme:     ps.Game.Theme().Board,
-	})
+		Large:     true,
+	}
+	if boardWidget.Width() > width || boardWidget.Height() > height {
+		boardWidget.Large = false
+	}
+	if ps.Hint != nil {
+		boardWidget.Highlights = ps.Hint.highlights
+	}
+	statusWidget := &ui.StatusWidget{Items: ps.getStatusItems()}
+
+	// the status is drawn at the right of the board if there is space,
+	// below the board otherwise
+	x := (width - boardWidget.Width()) / 2
+	y := (height - boardWidget.Height()) / 2
+	totalWidth := boardWidget.Width() + statusMargin + statusWidget.Width()
+	if totalWidth <= width {
+		x = (width - totalWidth) / 2
+		ps.Game.Client().Draw(x+boardWidget.Width()+statusMargin, y, statusWidget)
+	} else if y+boardWidget.Height()+statusWidget.Height() <= height {
+		ps.Game.Client().Draw(x, y+boardWidget.Height(), statusWidget)
+	}
+	ps.Game.Client().Draw(x, y, boardWidget)
+	ps.widget = boardWidget
+
+	if ps.Hint != nil {
+		ps.Game.Client().DrawAligned(&ui.TextWidget{String: ps.Hint.message()},
+			ui.HAlignCenter, ui.VAlignEnd)
+	}
+}
+
+func (ps *playState) getStatusItems() []ui.StatusItem {
+	mode := "Values"
+	if ps.NoteMode {
+		mode = "Notes"
+	}
+
+	items := []ui.StatusItem{
+		{Label: "Time", Value: formatDuration(ps.Game.Elapsed())},
+		{Label: "Level", Value: DifficultyName(ps.Game.Difficulty())},
+		{Label: "Hints", Value: fmt.Sprint(ps.Game.HintCount())},
+		{Label: "Mistakes", Value: fmt.Sprint(ps.Game.MistakeCount())},
+		{Label: "Mode", Value: mode},
+	}
+	if rules := ps.Game.Board().Rules(); rules != 0 {
+		items = append(items, ui.StatusItem{Label: "Rules", Value: getRulesName(rules)})
+	}
+	if ps.Game.PuzzleID() != "" {
+		items = append(items, ui.StatusItem{Label: "Puzzle", Value: ps.Game.PuzzleID()})
+	}
+	return items
 }
@@ -0,0 +1,244 @@
+package game
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/serhatsdev/sudoku/game/board"
+)
+
+// SaveSchemaVersion is the version of the save format written by this
+// version of the game. Saves without a schema version are version 0.
+const SaveSchemaVersion = 2
+
+// saveMigrations upgrade the raw save data, the migration
+// at index i upgrades a save from schema version i to i+1
+var saveMigrations = []func(data map[string]json.RawMessage) error{
+	migrateBoardData,
+	migrateCages,
+}
+
+// migrateSave upgrades the raw save data to the current schema version
+func migrateSave(data map[string]json.RawMessage) error {
+	version := 0
+	if rawVersion, exist := data["schema_version"]; exist {
+		err := json.Unmarshal(rawVersion, &version)
+		if err != nil || version < 0 {
+			return ErrSaveCorrupted
+		}
+	}
+
+	if version > SaveSchemaVersion {
+		return ErrSaveTooNew
+	}
+
+	for ; version < SaveSchemaVersion; version++ {
+		err := saveMigrations[version](data)
+		if err != nil {
+			return err
+		}
+	}
+
+	rawVersion, err := json.Marshal(SaveSchemaVersion)
+	if err != nil {
+		return err
+	}
+	data["schema_version"] = rawVersion
+	return nil
+}
+
+// migrateBoardData upgrades a version 0 save, it replaces the
+// dash separated board_data and the comma separated notes_data
+// strings with the board object. Version 0 saves without difficulty
+// are from the versions that only had medium boards.
+func migrateBoardData(data map[string]json.RawMessage) error {
+	boardData, notesData := "", ""
+	err := unmarshalField(data, "board_data", &boardData)
+	if err != nil {
+		return err
+	}
+	err = unmarshalField(data, "notes_data", &notesData)
+	if err != nil {
+		return err
+	}
+
+	b, err := loadBoard(boardData)
+	if err != nil {
+		return err
+	}
+	err = loadNotes(b, notesData)
+	if err != nil {
+		return err
+	}
+
+	rawBoard, err := json.Marshal(getBoardJSON(b))
+	if err != nil {
+		return err
+	}
+	data["board"] = rawBoard
+	delete(data, "board_data")
+	delete(data, "notes_data")
+
+	difficulty := byte(0)
+	err = unmarshalField(data, "difficulty", &difficulty)
+	if err != nil {
+		return err
+	}
+	if difficulty == 0 {
+		difficulty = board.Medium
+		data["difficulty"], _ = json.Marshal(difficulty)
+	}
+
+	lastDifficulty := byte(0)
+	err = unmarshalField(data, "last_difficulty", &lastDifficulty)
+	if err != nil {
+		return err
+	}
+	if lastDifficulty == 0 {
+		data["last_difficulty"] = data["difficulty"]
+	}
+
+	return nil
+}
+
+// migrateCages upgrades a version 1 save, it moves the
+// cages of the killer boards to the constraints of the board
+func migrateCages(data map[string]json.RawMessage) error {
+	boardData := map[string]json.RawMessage{}
+	err := unmarshalField(data, "board", &boardData)
+	if err != nil {
+		return err
+	}
+
+	cages := []CageJSON{}
+	err = unmarshalField(boardData, "cages", &cages)
+	if err != nil {
+		return err
+	}
+	if len(cages) == 0 {
+		return nil
+	}
+
+	rawConstraints, err := json.Marshal([]ConstraintJSON{{Rule: board.KillerRule.Code(), Cages: cages}})
+	if err != nil {
+		return err
+	}
+	boardData["constraints"] = rawConstraints
+	delete(boardData, "cages")
+
+	rawBoard, err := json.Marshal(boardData)
+	if err != nil {
+		return err
+	}
+	data["board"] = rawBoard
+	return nil
+}
+
+// unmarshalField reads the given field of the raw save data,
+// missing fields leave the value unchanged
+func unmarshalField(data map[string]json.RawMessage, field string, value interface{}) error {
+	rawValue, exist := data[field]
+	if !exist {
+		return nil
+	}
+
+	err := json.Unmarshal(rawValue, value)
+	if err != nil {
+		return ErrSaveCorrupted
+	}
+	return nil
+}
+
+func intToBool(value int) bool {
+	return value > 0
+}
+
+// legacySize is the size of the boards of the legacy saves
+var legacySize = board.Classic.Size()
+
+func getGridFromStringData(data string) (board.Grid, error) {
+	if len(data) != legacySize*legacySize {
+		return nil, ErrSaveCorrupted
+	}
+
+	grid := board.NewGrid(legacySize)
+	for i := 0; i < len(data); i++ {
+		rowIndex := int(i / legacySize)
+		columnIndex := i % legacySize
+
+		value, err := strconv.Atoi(string(data[i]))
+		if err != nil {
+			return nil, ErrSaveCorrupted
+		}
+
+		grid[rowIndex][columnIndex] = value
+	}
+
+	return grid, nil
+}
+
+func getPredefinedGridFromStringData(data string) ([][]bool, error) {
+	grid, err := getGridFromStringData(data)
+	if err != nil {
+		return nil, err
+	}
+
+	predefined := [][]bool{}
+	for _, row := range grid {
+		predefinedRow := []bool{}
+		for _, value := range row {
+			predefinedRow = append(predefinedRow, intToBool(value))
+		}
+		predefined = append(predefined, predefinedRow)
+	}
+
+	return predefined, nil
+}
+
+func loadBoard(boardData string) (board.Board, error) {
+	datas := strings.Split(boardData, "-")
+	if len(datas) != 3 {
+		return nil, ErrSaveCorrupted
+	}
+
+	uncompleteGrid, err := getGridFromStringData(datas[0])
+	if err != nil {
+		return nil, err
+	}
+	completeGrid, err := getGridFromStringData(datas[1])
+	if err != nil {
+		return nil, err
+	}
+	predefinedGrid, err := getPredefinedGridFromStringData(datas[2])
+	if err != nil {
+		return nil, err
+	}
+
+	return board.NewCustom(uncompleteGrid, completeGrid, predefinedGrid), nil
+}
+
+func loadNotes(b board.Board, notesData string) error {
+	if notesData == "" {
+		return nil
+	}
+
+	cellNotes := strings.Split(notesData, ",")
+	if len(cellNotes) != legacySize*legacySize {
+		return ErrSaveCorrupted
+	}
+
+	for i, notes := range cellNotes {
+		pos := board.Point2{X: i % legacySize, Y: i / legacySize}
+		for _, char := range notes {
+			note, err := strconv.Atoi(string(char))
+			if err != nil {
+				return ErrSaveCorrupted
+			}
+
+			b.ToggleNote(pos, note)
+		}
+	}
+
+	return nil
+}
@@ -6,8 +6,10 @@ import (
 	"fmt"
 	"os"
 	"path"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/serhatsdev/sudoku/game/board"
 	"github.com/serhatsdev/sudoku/game/theme"
@@ -17,101 +19,288 @@ var ErrNoSavedGame = errors.New("there is no saved game")
 
 var ErrSaveCorrupted = errors.New("save file is corrupted")
 
+var ErrSaveTooNew = errors.New("save file is from a newer version of the game")
+
 type SaveData struct {
-	Board board.Board
-	Theme theme.Theme
+	Name     string
+	PuzzleID string
+	// Daily is the date of the daily puzzle, empty for other games
+	Daily          string
+	LastPlayed     time.Time
+	Board          board.Board
+	Moves          []board.Move
+	MoveIndex      int
+	Difficulty     byte
+	LastDifficulty byte
+	HintCount      int
+	MistakeCount   int
+	Elapsed        time.Duration
+	Completed      bool
+	Theme          theme.Theme
 }
 
+// SaveDataJSON is the save format of the schema version SaveSchemaVersion,
+// older saves are upgraded by the save migrations before they are read
 type SaveDataJSON struct {
-	Version   string `json:"version"`
-	BoardData string `json:"board_data"`
-	ThemeName string `json:"theme_name"`
+	SchemaVersion  int        `json:"schema_version"`
+	Version        string     `json:"version"`
+	Name           string     `json:"name"`
+	PuzzleID       string     `json:"puzzle_id,omitempty"`
+	Daily          string     `json:"daily,omitempty"`
+	LastPlayed     string     `json:"last_played"`
+	Board          BoardJSON  `json:"board"`
+	History        []MoveJSON `json:"history"`
+	HistoryIndex   int        `json:"history_index"`
+	Difficulty     byte       `json:"difficulty"`
+	LastDifficulty byte       `json:"last_difficulty"`
+	Hints          int        `json:"hints"`
+	Mistakes       int        `json:"mistakes"`
+	ElapsedMS      int64      `json:"elapsed_ms"`
+	Completed      bool       `json:"completed"`
+	ThemeName      string     `json:"theme_name"`
 }
 
-func boolToInt(value bool) int {
-	if value {
-		return 1
-	}
+// BoardJSON holds the cells of a board row by row
+type BoardJSON struct {
+	Values     [][]int   `json:"values"`
+	Solution   [][]int   `json:"solution"`
+	Predefined [][]bool  `json:"predefined"`
+	Notes      [][][]int `json:"notes"`
+	// Constraints are the rules of the variant boards
+	Constraints []ConstraintJSON `json:"constraints,omitempty"`
+}
 
-	return 0
+// ConstraintJSON holds a constraint by the code of its rule, the cages,
+// the regions and the even and odd cells are the data of
+// the killer, jigsaw and even/odd constraints
+type ConstraintJSON struct {
+	Rule  string     `json:"rule"`
+	Cages []CageJSON `json:"cages,omitempty"`
+	// Regions are the region indexes of the cells row by row
+	Regions [][]int  `json:"regions,omitempty"`
+	Even    [][2]int `json:"even,omitempty"`
+	Odd     [][2]int `json:"odd,omitempty"`
 }
 
-func intToBool(value int) bool {
-	return value > 0
+// CageJSON holds the sum and the x, y positions of the cells of a cage
+type CageJSON struct {
+	Sum   int      `json:"sum"`
+	Cells [][2]int `json:"cells"`
 }
 
-func getBoardData(b board.Board) string {
-	valueData := ""
-	correctData := ""
-	predefinedData := ""
+type MoveJSON []ChangeJSON
+
+type ChangeJSON struct {
+	X      int           `json:"x"`
+	Y      int           `json:"y"`
+	Before CellStateJSON `json:"before"`
+	After  CellStateJSON `json:"after"`
+}
+
+type CellStateJSON struct {
+	Value int   `json:"value"`
+	Notes []int `json:"notes,omitempty"`
+}
 
-	for i := 0; i < board.Size; i++ {
-		for j := 0; j < board.Size; j++ {
+func getBoardJSON(b board.Board) BoardJSON {
+	boardJSON := BoardJSON{}
+	size := b.Dimensions().Size()
+	for i := 0; i < size; i++ {
+		values := []int{}
+		solution := []int{}
+		predefined := []bool{}
+		notes := [][]int{}
+		for j := 0; j < size; j++ {
 			pos := board.Point2{X: j, Y: i}
+			values = append(values, b.Get(pos))
+			solution = append(solution, b.GetCorrect(pos))
+			predefined = append(predefined, b.IsPredefined(pos))
+			notes = append(notes, b.GetNotes(pos))
+		}
+
+		boardJSON.Values = append(boardJSON.Values, values)
+		boardJSON.Solution = append(boardJSON.Solution, solution)
+		boardJSON.Predefined = append(boardJSON.Predefined, predefined)
+		boardJSON.Notes = append(boardJSON.Notes, notes)
+	}
+
+	for _, constraint := range b.Constraints() {
+		boardJSON.Constraints = append(boardJSON.Constraints, getConstraintJSON(constraint))
+	}
+	return boardJSON
+}
 
-			valueData += fmt.Sprint(b.Get(pos))
-			correctData += fmt.Sprint(b.GetCorrect(pos))
-			predefinedData += fmt.Sprint(boolToInt(b.IsPredefined(pos)))
+func getConstraintJSON(constraint board.Constraint) ConstraintJSON {
+	constraintJSON := ConstraintJSON{Rule: constraint.Rule().Code()}
+	switch constraint := constraint.(type) {
+	case board.Cages:
+		for _, cage := range constraint {
+			cageJSON := CageJSON{Sum: cage.Sum, Cells: getCellsJSON(cage.Cells)}
+			constraintJSON.Cages = append(constraintJSON.Cages, cageJSON)
 		}
+	case board.Regions:
+		constraintJSON.Regions = constraint
+	case board.EvenOdd:
+		constraintJSON.Even = getCellsJSON(constraint.Even)
+		constraintJSON.Odd = getCellsJSON(constraint.Odd)
 	}
+	return constraintJSON
+}
 
-	return strings.Join([]string{valueData, correctData, predefinedData}, "-")
+// getCellsJSON returns the x, y positions of the given cells
+func getCellsJSON(cells []board.Point2) [][2]int {
+	cellsJSON := [][2]int{}
+	for _, pos := range cells {
+		cellsJSON = append(cellsJSON, [2]int{pos.X, pos.Y})
+	}
+	return cellsJSON
 }
 
-func getGridFromStringData(data string) (board.Grid, error) {
-	grid := board.Grid{}
-	for i := 0; i < len(data); i++ {
-		rowIndex := int(i / board.Size)
-		columnIndex := i % board.Size
+// loadCellsJSON returns the cells of the given x, y positions
+func loadCellsJSON(cellsJSON [][2]int) []board.Point2 {
+	cells := []board.Point2{}
+	for _, cell := range cellsJSON {
+		cells = append(cells, board.Point2{X: cell[0], Y: cell[1]})
+	}
+	return cells
+}
 
-		value, err := strconv.Atoi(string(data[i]))
-		if err != nil {
-			return board.Grid{}, ErrSaveCorrupted
-		}
+// loadConstraintJSON returns the saved constraint
+func loadConstraintJSON(constraintJSON ConstraintJSON) (board.Constraint, error) {
+	rule, err := board.ParseRuleCodes(constraintJSON.Rule)
+	if err != nil || len(constraintJSON.Rule) != 1 {
+		return nil, ErrSaveCorrupted
+	}
 
-		grid[rowIndex][columnIndex] = value
+	switch rule {
+	case board.KillerRule:
+		cages := board.Cages{}
+		for _, cageJSON := range constraintJSON.Cages {
+			cages = append(cages, board.Cage{Sum: cageJSON.Sum, Cells: loadCellsJSON(cageJSON.Cells)})
+		}
+		return cages, nil
+	case board.JigsawRule:
+		return board.Regions(constraintJSON.Regions), nil
+	case board.EvenOddRule:
+		return board.EvenOdd{
+			Even: loadCellsJSON(constraintJSON.Even),
+			Odd:  loadCellsJSON(constraintJSON.Odd),
+		}, nil
 	}
 
-	return grid, nil
+	constraint, exist := board.NewConstraint(rule)
+	if !exist {
+		return nil, ErrSaveCorrupted
+	}
+	return constraint, nil
 }
 
-func getPredefinedGridFromStringData(data string) ([board.Size][board.Size]bool, error) {
-	grid := [board.Size][board.Size]bool{}
-	for i := 0; i < len(data); i++ {
-		rowIndex := int(i / board.Size)
-		columnIndex := i % board.Size
+// loadBoardJSON returns the saved board,
+// the number of rows decides the size of the board
+func loadBoardJSON(boardJSON BoardJSON) (board.Board, error) {
+	size := len(boardJSON.Values)
+	if _, err := board.DimensionsOf(size); err != nil {
+		return nil, ErrSaveCorrupted
+	}
+	if !isSquare(size, len(boardJSON.Solution), len(boardJSON.Predefined), len(boardJSON.Notes)) {
+		return nil, ErrSaveCorrupted
+	}
 
-		value, err := strconv.Atoi(string(data[i]))
-		if err != nil {
-			return [board.Size][board.Size]bool{}, ErrSaveCorrupted
+	values := board.NewGrid(size)
+	solution := board.NewGrid(size)
+	predefined := [][]bool{}
+	for i := 0; i < size; i++ {
+		if !isSquare(size, len(boardJSON.Values[i]), len(boardJSON.Solution[i]),
+			len(boardJSON.Predefined[i]), len(boardJSON.Notes[i])) {
+			return nil, ErrSaveCorrupted
 		}
 
-		grid[rowIndex][columnIndex] = intToBool(value)
+		for j := 0; j < size; j++ {
+			value, correct := boardJSON.Values[i][j], boardJSON.Solution[i][j]
+			if value < 0 || value > size || correct < 1 || correct > size {
+				return nil, ErrSaveCorrupted
+			}
+
+			values[i][j] = value
+			solution[i][j] = correct
+		}
+		predefined = append(predefined, boardJSON.Predefined[i])
 	}
 
-	return grid, nil
-}
+	constraints := []board.Constraint{}
+	for _, constraintJSON := range boardJSON.Constraints {
+		constraint, err := loadConstraintJSON(constraintJSON)
+		if err != nil {
+			return nil, err
+		}
+		constraints = append(constraints, constraint)
+	}
 
-func loadBoard(boardData string) (board.Board, error) {
-	datas := strings.Split(boardData, "-")
-	if len(datas) != 3 {
+	// the solution must keep every constraint
+	if board.ValidateConstraints(solution, constraints...) != nil {
 		return nil, ErrSaveCorrupted
 	}
 
-	uncompleteGrid, err := getGridFromStringData(datas[0])
-	if err != nil {
-		return nil, err
+	b := board.NewCustom(values, solution, predefined, constraints...)
+	for i, row := range boardJSON.Notes {
+		for j, notes := range row {
+			for _, note := range notes {
+				if note < 1 || note > size {
+					return nil, ErrSaveCorrupted
+				}
+				b.ToggleNote(board.Point2{X: j, Y: i}, note)
+			}
+		}
 	}
-	completeGrid, err := getGridFromStringData(datas[1])
-	if err != nil {
-		return nil, err
+	return b, nil
+}
+
+// isSquare returns if all of the given lengths equal to the board size
+func isSquare(size int, lengths ...int) bool {
+	for _, length := range lengths {
+		if length != size {
+			return false
+		}
 	}
-	predefinedGrid, err := getPredefinedGridFromStringData(datas[2])
-	if err != nil {
-		return nil, err
+	return true
+}
+
+func getHistoryData(moves []board.Move) []MoveJSON {
+	movesJSON := []MoveJSON{}
+	for _, move := range moves {
+		moveJSON := MoveJSON{}
+		for _, change := range move {
+			moveJSON = append(moveJSON, ChangeJSON{
+				X:      change.Pos.X,
+				Y:      change.Pos.Y,
+				Before: CellStateJSON{change.Before.Value, change.Before.Notes},
+				After:  CellStateJSON{change.After.Value, change.After.Notes},
+			})
+		}
+		movesJSON = append(movesJSON, moveJSON)
 	}
+	return movesJSON
+}
 
-	return board.NewCustom(uncompleteGrid, completeGrid, predefinedGrid), nil
+// loadHistory returns the saved moves of a board of the given size
+func loadHistory(movesJSON []MoveJSON, size int) ([]board.Move, error) {
+	moves := []board.Move{}
+	for _, moveJSON := range movesJSON {
+		move := board.Move{}
+		for _, change := range moveJSON {
+			if change.X < 0 || change.X >= size || change.Y < 0 || change.Y >= size {
+				return nil, ErrSaveCorrupted
+			}
+
+			move = append(move, board.Change{
+				Pos:    board.Point2{X: change.X, Y: change.Y},
+				Before: board.CellState{Value: change.Before.Value, Notes: change.Before.Notes},
+				After:  board.CellState{Value: change.After.Value, Notes: change.After.Notes},
+			})
+		}
+		moves = append(moves, move)
+	}
+	return moves, nil
 }
 
 func getFirstThemeByNameOrDefault(themes []theme.Theme, name string) theme.Theme {
@@ -124,64 +313,397 @@ func getFirstThemeByNameOrDefault(themes []theme.Theme, name string) theme.Theme
 	return themes[0]
 }
 
-func getSaveFile() (string, error) {
+func getSavesDirectory() (string, error) {
 	configDir, err := os.UserConfigDir()
 	if err != nil {
 		return "", err
 	}
 
-	return path.Join(configDir, "sudoku", "save.json"), nil
+	return path.Join(configDir, "sudoku", "saves"), nil
 }
 
-func LoadSavedGame() (SaveData, error) {
-	saveFile, err := getSaveFile()
+func getSlotFile(slot string) (string, error) {
+	savesDir, err := getSavesDirectory()
 	if err != nil {
-		return SaveData{}, err
+		return "", err
+	}
+
+	return path.Join(savesDir, slot+".json"), nil
+}
+
+// migrateLegacySave moves the single save file of the
+// older versions into the saves directory as a slot
+func migrateLegacySave() error {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return err
+	}
+
+	legacyFile := path.Join(configDir, "sudoku", "save.json")
+	if _, err := os.Stat(legacyFile); err != nil {
+		return nil
+	}
+
+	slotFile, err := getSlotFile(NewSaveSlotID())
+	if err != nil {
+		return err
+	}
+	err = os.MkdirAll(path.Dir(slotFile), os.ModePerm)
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(legacyFile, slotFile)
+}
+
+// NewSaveSlotID returns a new unique save slot id
+func NewSaveSlotID() string {
+	return strconv.FormatInt(time.Now().UnixNano(), 36)
+}
+
+// SaveSlot is the summary of a saved game shown in the load game menu
+type SaveSlot struct {
+	ID         string
+	Name       string
+	Difficulty byte
+	Daily      string
+	// Progress is the percentage of the empty cells filled correctly
+	Progress   int
+	Elapsed    time.Duration
+	LastPlayed time.Time
+	Completed  bool
+}
+
+// ListSaveSlots returns the save slots, the most recently played first.
+// Unreadable slots are skipped.
+func ListSaveSlots() ([]SaveSlot, error) {
+	err := migrateLegacySave()
+	if err != nil {
+		return nil, err
 	}
 
-	file, err := os.ReadFile(saveFile)
+	savesDir, err := getSavesDirectory()
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := os.ReadDir(savesDir)
+	if os.IsNotExist(err) {
+		return []SaveSlot{}, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	slots := []SaveSlot{}
+	for _, entry := range entries {
+		if entry.IsDir() || path.Ext(entry.Name()) != ".json" {
+			continue
+		}
+
+		slot := strings.TrimSuffix(entry.Name(), ".json")
+		savedata, err := LoadSavedGame(slot)
+		if err != nil {
+			continue
+		}
+
+		slots = append(slots, SaveSlot{
+			ID:         slot,
+			Name:       savedata.Name,
+			Difficulty: savedata.Difficulty,
+			Daily:      savedata.Daily,
+			Progress:   getProgress(savedata.Board),
+			Elapsed:    savedata.Elapsed,
+			LastPlayed: savedata.LastPlayed,
+			Completed:  savedata.Completed,
+		})
+	}
+
+	sort.SliceStable(slots, func(i, j int) bool {
+		return slots[i].LastPlayed.After(slots[j].LastPlayed)
+	})
+	return slots, nil
+}
+
+// LoadLastSavedGame loads the most recently played game
+func LoadLastSavedGame() (SaveData, error) {
+	slots, err := ListSaveSlots()
 	if err != nil {
 		return SaveData{}, err
 	}
+	if len(slots) == 0 {
+		return SaveData{}, ErrNoSavedGame
+	}
+
+	return LoadSavedGame(slots[0].ID)
+}
+
+// getProgress returns the percentage of the
+// empty cells of the board filled correctly
+func getProgress(b board.Board) int {
+	empty, correct := 0, 0
+	size := b.Dimensions().Size()
+	for i := 0; i < size; i++ {
+		for j := 0; j < size; j++ {
+			pos := board.Point2{X: j, Y: i}
+			if b.IsPredefined(pos) {
+				continue
+			}
+
+			empty++
+			if b.IsCorrect(pos) {
+				correct++
+			}
+		}
+	}
+
+	if empty == 0 {
+		return 100
+	}
+	return correct * 100 / empty
+}
+
+func readSlot(slot string) (SaveDataJSON, error) {
+	slotFile, err := getSlotFile(slot)
+	if err != nil {
+		return SaveDataJSON{}, err
+	}
+
+	file, err := os.ReadFile(slotFile)
+	if os.IsNotExist(err) {
+		return SaveDataJSON{}, ErrNoSavedGame
+	} else if err != nil {
+		return SaveDataJSON{}, err
+	}
+
+	data := map[string]json.RawMessage{}
+	err = json.Unmarshal(file, &data)
+	if err != nil {
+		return SaveDataJSON{}, ErrSaveCorrupted
+	}
+	err = migrateSave(data)
+	if err != nil {
+		return SaveDataJSON{}, err
+	}
 
+	file, err = json.Marshal(data)
+	if err != nil {
+		return SaveDataJSON{}, err
+	}
 	savedatajson := SaveDataJSON{}
-	json.Unmarshal(file, &savedatajson)
+	err = json.Unmarshal(file, &savedatajson)
+	if err != nil {
+		return SaveDataJSON{}, ErrSaveCorrupted
+	}
+	return savedatajson, nil
+}
+
+// backupCorruptedSave moves the save file of the given slot to the
+// corrupted directory, so it is neither listed nor overwritten
+func backupCorruptedSave(slot string) error {
+	slotFile, err := getSlotFile(slot)
+	if err != nil {
+		return err
+	}
+
+	backupFile := path.Join(path.Dir(slotFile), "corrupted",
+		fmt.Sprintf("%s-%d.json", slot, time.Now().Unix()))
+	err = os.MkdirAll(path.Dir(backupFile), os.ModePerm)
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(slotFile, backupFile)
+}
+
+func writeSlot(slot string, savedatajson SaveDataJSON) error {
+	slotFile, err := getSlotFile(slot)
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(path.Dir(slotFile), os.ModePerm)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(savedatajson)
+	if err != nil {
+		return err
+	}
+
+	// the previous save is kept to recover from a corrupted save
+	previous, err := os.ReadFile(slotFile)
+	if err == nil {
+		err = writeFileAtomic(getBackupFile(slotFile), previous)
+		if err != nil {
+			return err
+		}
+	}
+
+	return writeFileAtomic(slotFile, data)
+}
+
+func getBackupFile(slotFile string) string {
+	return slotFile + ".bak"
+}
+
+// writeFileAtomic writes the data to a temporary file and renames it
+// to the given file, so the file is never left partially written
+func writeFileAtomic(file string, data []byte) error {
+	tempFile, err := os.CreateTemp(path.Dir(file), path.Base(file)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tempFile.Name())
+
+	_, err = tempFile.Write(data)
+	if err == nil {
+		err = tempFile.Sync()
+	}
+	if closeErr := tempFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tempFile.Name(), file)
+}
+
+// restoreBackup replaces the save file of the given slot with its backup
+func restoreBackup(slot string) error {
+	slotFile, err := getSlotFile(slot)
+	if err != nil {
+		return err
+	}
+
+	backup, err := os.ReadFile(getBackupFile(slotFile))
+	if err != nil {
+		return err
+	}
+
+	return writeFileAtomic(slotFile, backup)
+}
+
+// LoadSavedGame loads the game saved to the given slot. Older saves are
+// upgraded to the current schema, unreadable saves are moved to the
+// corrupted directory and the game is recovered from the backup of
+// the previous save, ErrSaveCorrupted is returned if that fails too.
+func LoadSavedGame(slot string) (SaveData, error) {
+	savedata, err := loadSlot(slot)
+	if !errors.Is(err, ErrSaveCorrupted) {
+		return savedata, err
+	}
+
+	backupCorruptedSave(slot)
+	if restoreBackup(slot) != nil {
+		return SaveData{}, err
+	}
+
+	savedata, err = loadSlot(slot)
+	if errors.Is(err, ErrSaveCorrupted) {
+		backupCorruptedSave(slot)
+	}
+	return savedata, err
+}
+
+func loadSlot(slot string) (SaveData, error) {
+	savedatajson, err := readSlot(slot)
+	if err != nil {
+		return SaveData{}, err
+	}
 
 	themes, err := theme.GetThemes()
 	if err != nil {
 		return SaveData{}, err
 	}
 
-	board, err := loadBoard(savedatajson.BoardData)
+	board, err := loadBoardJSON(savedatajson.Board)
+	if err != nil {
+		return SaveData{}, err
+	}
+	moves, err := loadHistory(savedatajson.History, board.Dimensions().Size())
 	if err != nil {
 		return SaveData{}, err
 	}
 	theme := getFirstThemeByNameOrDefault(themes, savedatajson.ThemeName)
 
+	name := savedatajson.Name
+	if name == "" {
+		name = DifficultyName(savedatajson.Difficulty)
+	}
+	lastPlayed, _ := time.Parse(time.RFC3339Nano, savedatajson.LastPlayed)
+
 	savedata := SaveData{
-		Board: board,
-		Theme: theme,
+		Name:           name,
+		PuzzleID:       savedatajson.PuzzleID,
+		Daily:          savedatajson.Daily,
+		LastPlayed:     lastPlayed,
+		Board:          board,
+		Moves:          moves,
+		MoveIndex:      savedatajson.HistoryIndex,
+		Difficulty:     savedatajson.Difficulty,
+		LastDifficulty: savedatajson.LastDifficulty,
+		HintCount:      savedatajson.Hints,
+		MistakeCount:   savedatajson.Mistakes,
+		Elapsed:        time.Duration(savedatajson.ElapsedMS) * time.Millisecond,
+		Completed:      savedatajson.Completed,
+		Theme:          theme,
 	}
 
 	return savedata, nil
 }
 
-func SaveGame(savedata SaveData) error {
-	saveFile, err := getSaveFile()
+// SaveGame saves the game to the given slot
+func SaveGame(slot string, savedata SaveData) error {
+	savedatajson := SaveDataJSON{
+		SchemaVersion:  SaveSchemaVersion,
+		Version:        Version,
+		Name:           savedata.Name,
+		PuzzleID:       savedata.PuzzleID,
+		Daily:          savedata.Daily,
+		LastPlayed:     savedata.LastPlayed.Format(time.RFC3339Nano),
+		ThemeName:      savedata.Theme.Name,
+		Board:          getBoardJSON(savedata.Board),
+		History:        getHistoryData(savedata.Moves),
+		HistoryIndex:   savedata.MoveIndex,
+		Difficulty:     savedata.Difficulty,
+		LastDifficulty: savedata.LastDifficulty,
+		Hints:          savedata.HintCount,
+		Mistakes:       savedata.MistakeCount,
+		ElapsedMS:      savedata.Elapsed.Milliseconds(),
+		Completed:      savedata.Completed,
+	}
+
+	return writeSlot(slot, savedatajson)
+}
+
+// RenameSaveSlot changes the name of the game saved to the given slot
+func RenameSaveSlot(slot, name string) error {
+	savedatajson, err := readSlot(slot)
 	if err != nil {
 		return err
 	}
 
-	savedatajson := SaveDataJSON{
-		Version:   Version,
-		ThemeName: savedata.Theme.Name,
-		BoardData: getBoardData(savedata.Board),
+	savedatajson.Name = name
+	return writeSlot(slot, savedatajson)
+}
+
+// DeleteSaveSlot removes the game saved to the given slot
+func DeleteSaveSlot(slot string) error {
+	slotFile, err := getSlotFile(slot)
+	if err != nil {
+		return err
 	}
 
-	data, err := json.Marshal(savedatajson)
+	err = os.Remove(slotFile)
 	if err != nil {
 		return err
 	}
 
-	return os.WriteFile(saveFile, data, os.ModePerm)
+	err = os.Remove(getBackupFile(slotFile))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
 }
@@ -0,0 +1,83 @@
+package game
+
+import (
+	"encoding/json"
+	"os"
+	"path"
+
+	"github.com/serhatsdev/sudoku/game/board"
+)
+
+type Settings struct {
+	// AutoRemoveNotes removes the placed value
+	// from the notes of the cells in the same row, column and box
+	AutoRemoveNotes bool `json:"auto_remove_notes"`
+	// Symmetry is the clue layout of the new games
+	Symmetry board.Symmetry `json:"symmetry"`
+	// Size is the number of rows and columns of the new games
+	Size int `json:"size"`
+	// Rules are the variant rules of the new games
+	Rules board.Rule `json:"rules"`
+}
+
+// DefaultSettings returns the settings used
+// when there is no settings file
+func DefaultSettings() Settings {
+	return Settings{
+		AutoRemoveNotes: true,
+		Symmetry:        board.Rotational,
+		Size:            board.Classic.Size(),
+	}
+}
+
+func getSettingsFile() (string, error) {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+
+	return path.Join(configDir, "sudoku", "settings.json"), nil
+}
+
+func LoadSettings() (Settings, error) {
+	settingsFile, err := getSettingsFile()
+	if err != nil {
+		return Settings{}, err
+	}
+
+	file, err := os.ReadFile(settingsFile)
+	if err != nil {
+		return Settings{}, err
+	}
+
+	settings := DefaultSettings()
+	err = json.Unmarshal(file, &settings)
+	if err != nil {
+		return Settings{}, err
+	}
+
+	if _, err := board.DimensionsOf(settings.Size); err != nil {
+		settings.Size = board.Classic.Size()
+	}
+
+	return settings, nil
+}
+
+func SaveSettings(settings Settings) error {
+	settingsFile, err := getSettingsFile()
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(path.Dir(settingsFile), os.ModePerm)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(settings)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(settingsFile, data, os.ModePerm)
+}
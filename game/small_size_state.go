@@ -22,6 +22,8 @@ func (sss *smallSizeState) OnResize(width, height int) {
 
 func (sss *smallSizeState) OnKeyPress(key string) {}
 
+func (sss *smallSizeState) OnMouse(x, y int, button string) {}
+
 func (sss *smallSizeState) Draw() {
 	message := fmt.Sprintf("Please resize to\n at least %dx%d",
 		sss.Game.MinWidth(), sss.Game.MinHeight())
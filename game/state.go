@@ -1,7 +1,11 @@
 package game
 
 import (
+	"os"
+	"time"
+
 	"github.com/serhatsdev/sudoku/game/board"
+	"github.com/serhatsdev/sudoku/game/export"
 	"github.com/serhatsdev/sudoku/game/theme"
 )
 
@@ -10,22 +14,26 @@ import (
 //
 // - What to do when game resized
 // - What to do when key pressed
+// - What to do when mouse clicked
 // - What to draw
 type State interface {
 	// OnResize will be invoked when terminal resized
 	OnResize(width, height int)
 	// OnKeyPress will be invoked when key pressed
 	OnKeyPress(key string)
+	// OnMouse will be invoked when mouse button pressed
+	// or wheel scrolled at the given screen position
+	OnMouse(x, y int, button string)
 	// Draw will be invoked after every event
 	Draw()
 }
 
 // NewPlayState returns a new play state
 func NewPlayState(game Game) State {
+	center := game.Board().Dimensions().Size() / 2
 	return &playState{
 		Game: game,
-		// center of the board
-		Pos: board.Point2{X: 4, Y: 4},
+		Pos:  board.Point2{X: center, Y: center},
 	}
 }
 
@@ -34,6 +42,338 @@ func NewSmallSizeState(game Game, width, height int) State {
 	return &smallSizeState{game, width, height}
 }
 
+// NewCompleteState returns a new state that shows
+// the results of the solved board
+func NewCompleteState(game Game) State {
+	return &completeState{
+		menuState: menuState{
+			Game: game,
+			Options: []menuOption{
+				{"New Game", func() {
+					game.PushState(NewDifficultyMenuState(game))
+				}},
+				{"Same Difficulty", func() {
+					// imported boards continue with the last generated difficulty
+					difficulty := game.Difficulty()
+					if !isGenerated(difficulty) {
+						difficulty = game.LastDifficulty()
+					}
+					size, rules := game.Board().Dimensions().Size(), game.Board().Rules()
+					if game.StartPuzzle(board.NewPuzzleID(size, difficulty, game.Settings().Symmetry, rules)) == nil {
+						game.PopState()
+					}
+				}},
+				{"Menu", func() {
+					game.ChangeState(NewMenuState(game))
+				}},
+			},
+		},
+	}
+}
+
+// NewDifficultyMenuState returns a menu state that starts a new game
+// with the chosen difficulty or puzzle id and returns to the play state,
+// the symmetry, size and rules options change the clue layout and the board of the new games
+func NewDifficultyMenuState(game Game) State {
+	ms := &menuState{Game: game}
+	rulesIndex := len(difficulties) + 3
+
+	for i, difficulty := range difficulties {
+		difficulty := difficulty
+		if difficulty == game.LastDifficulty() {
+			ms.Pos = i
+		}
+
+		ms.Options = append(ms.Options, menuOption{
+			title: DifficultyName(difficulty),
+			function: func() {
+				settings := game.Settings()
+				err := game.StartPuzzle(board.NewPuzzleID(settings.Size, difficulty, settings.Symmetry, settings.Rules))
+				if err != nil {
+					ms.Options[rulesIndex].title = "Unsupported: " + getRulesName(settings.Rules)
+					return
+				}
+				returnToPlayState(game)
+			},
+		})
+	}
+
+	ms.Options = append(ms.Options, menuOption{"Puzzle ID", func() {
+		game.PushState(NewInputState(game, "Puzzle ID", "", 32, func(text string) error {
+			id, err := board.ParsePuzzleID(text)
+			if err != nil {
+				return err
+			}
+
+			err = game.StartPuzzle(id)
+			if err != nil {
+				return err
+			}
+			returnToPlayState(game)
+			return nil
+		}))
+	}})
+
+	symmetryIndex := len(ms.Options)
+	ms.Options = append(ms.Options, menuOption{getSymmetryTitle(game.Settings().Symmetry), func() {
+		settings := game.Settings()
+		settings.Symmetry = getNextSymmetry(settings.Symmetry)
+		game.SetSettings(settings)
+
+		ms.Options[symmetryIndex].title = getSymmetryTitle(settings.Symmetry)
+	}})
+
+	sizeIndex := len(ms.Options)
+	ms.Options = append(ms.Options, menuOption{getSizeTitle(game.Settings().Size), func() {
+		settings := game.Settings()
+		settings.Size = getNextSize(settings.Size)
+		game.SetSettings(settings)
+
+		ms.Options[sizeIndex].title = getSizeTitle(settings.Size)
+		ms.Options[rulesIndex].title = getRulesTitle(settings.Size, settings.Rules)
+	}})
+
+	ms.Options = append(ms.Options, menuOption{getRulesTitle(game.Settings().Size, game.Settings().Rules), func() {
+		game.PushState(NewRulesMenuState(game, func(rules board.Rule) {
+			ms.Options[rulesIndex].title = getRulesTitle(game.Settings().Size, rules)
+		}))
+	}})
+
+	return ms
+}
+
+// NewRulesMenuState returns a menu state that toggles the variant
+// rules of the new games, onChange is called with the changed rules.
+// The rules the size can not have with the other rules can not be turned on.
+func NewRulesMenuState(game Game, onChange func(rules board.Rule)) State {
+	ms := &menuState{Game: game}
+	for _, rule := range board.Rules {
+		rule := rule
+
+		ms.Options = append(ms.Options, menuOption{function: func() {
+			settings := game.Settings()
+			if !canToggleRule(settings, rule) {
+				return
+			}
+
+			settings.Rules ^= rule
+			game.SetSettings(settings)
+
+			setRulesTitles(ms, settings)
+			onChange(settings.Rules)
+		}})
+	}
+
+	setRulesTitles(ms, game.Settings())
+	return ms
+}
+
+// setRulesTitles sets the titles of the rule options of the rules menu
+func setRulesTitles(ms *menuState, settings Settings) {
+	for i, rule := range board.Rules {
+		if canToggleRule(settings, rule) {
+			ms.Options[i].title = getToggleTitle(rule.String(), settings.Rules.Has(rule))
+		} else {
+			ms.Options[i].title = "Unsupported: " + rule.String()
+		}
+	}
+}
+
+// canToggleRule returns if the rule can be toggled, the rules
+// can always be turned off but only the supported ones turned on
+func canToggleRule(settings Settings, rule board.Rule) bool {
+	return settings.Rules.Has(rule) || supportsRules(settings.Size, settings.Rules|rule)
+}
+
+// NewLoadGameMenuState returns a new state that lists the save slots,
+// choosing a slot shows the actions for it
+func NewLoadGameMenuState(game Game) State {
+	slots, err := ListSaveSlots()
+	if err != nil {
+		slots = []SaveSlot{}
+	}
+
+	ls := &loadGameState{menuState: menuState{Game: game}, Slots: slots}
+	for _, slot := range slots {
+		slot := slot
+
+		title := slot.Name
+		if slot.ID == game.SaveSlot() {
+			title += " (current)"
+		}
+
+		ls.Options = append(ls.Options, menuOption{
+			title: title,
+			function: func() {
+				game.PushState(NewSlotMenuState(game, slot))
+			},
+		})
+	}
+
+	if len(ls.Options) == 0 {
+		ls.Options = []menuOption{
+			{"No Saved Games", func() {
+				game.PopState()
+			}},
+		}
+	}
+
+	return ls
+}
+
+// NewSlotMenuState returns a menu state that loads, renames
+// or deletes the given save slot. The current slot can not be deleted.
+func NewSlotMenuState(game Game, slot SaveSlot) State {
+	ms := &menuState{Game: game}
+	ms.Options = []menuOption{
+		{"Load", func() {
+			if game.LoadSlot(slot.ID) == nil {
+				returnToPlayState(game)
+			}
+		}},
+		{"Rename", func() {
+			game.PushState(NewInputState(game, "Rename", slot.Name, 24, func(name string) error {
+				err := game.RenameSlot(slot.ID, name)
+				if err != nil {
+					return err
+				}
+
+				game.PopState()
+				game.PopState()
+				reloadLoadGameMenu(game, slot.ID)
+				return nil
+			}))
+		}},
+	}
+
+	if slot.ID != game.SaveSlot() {
+		ms.Options = append(ms.Options, menuOption{"Delete", func() {
+			game.PushState(&menuState{
+				Game: game,
+				Pos:  1,
+				Options: []menuOption{
+					{"Delete " + slot.Name, func() {
+						game.DeleteSlot(slot.ID)
+						game.PopState()
+						game.PopState()
+						reloadLoadGameMenu(game, "")
+					}},
+					{"Cancel", func() {
+						game.PopState()
+					}},
+				},
+			})
+		}})
+	}
+
+	return ms
+}
+
+// NewInputState returns a new state that reads a line of text up to
+// maxLength characters starting with the given value and passes it
+// to onSubmit, the returned error is shown below the text
+func NewInputState(game Game, title, value string, maxLength int, onSubmit func(value string) error) State {
+	return &inputState{
+		Game:      game,
+		Title:     title,
+		Value:     value,
+		MaxLength: maxLength,
+		OnSubmit:  onSubmit,
+	}
+}
+
+// NewExportMenuState returns a menu state that exports the current
+// board to a file in the chosen format, the toggles change what is exported
+func NewExportMenuState(game Game) State {
+	options := export.Options{}
+	ms := &menuState{Game: game}
+
+	for _, format := range export.Formats() {
+		format := format
+		if !format.Supports(game.Board()) {
+			ms.Options = append(ms.Options, menuOption{"Unsupported: " + format.Title(), func() {}})
+			continue
+		}
+
+		ms.Options = append(ms.Options, menuOption{
+			title: format.Title(),
+			function: func() {
+				file := "sudoku" + format.Extension()
+				game.PushState(NewInputState(game, "Export File", file, 256, func(file string) error {
+					data, err := export.Export(game.Board(), format, options)
+					if err != nil {
+						return err
+					}
+					err = os.WriteFile(file, []byte(data), 0644)
+					if err != nil {
+						return withoutPath(err)
+					}
+
+					returnToPlayState(game)
+					return nil
+				}))
+			},
+		})
+	}
+
+	notesIndex := len(ms.Options)
+	ms.Options = append(ms.Options,
+		menuOption{getToggleTitle("Notes", options.Notes), func() {
+			options.Notes = !options.Notes
+			ms.Options[notesIndex].title = getToggleTitle("Notes", options.Notes)
+		}},
+		menuOption{getToggleTitle("Puzzle Only", options.PuzzleOnly), func() {
+			options.PuzzleOnly = !options.PuzzleOnly
+			ms.Options[notesIndex+1].title = getToggleTitle("Puzzle Only", options.PuzzleOnly)
+		}},
+	)
+
+	return ms
+}
+
+// NewDailyState returns a new state that shows the daily puzzle
+// of today and the daily streak, playing continues today's
+// unfinished daily puzzle if there is one. The days are in UTC,
+// so everyone plays the same puzzle and keeps the same streak.
+func NewDailyState(game Game) State {
+	today := time.Now().UTC()
+	ds := &dailyState{menuState: menuState{Game: game}, Today: today}
+
+	title := "Play"
+	if _, solved := game.Statistics().Daily.SolveTime(formatDate(today)); solved {
+		title = "Play Again"
+	}
+
+	ds.Options = []menuOption{
+		{title, func() {
+			date := formatDate(today)
+			if game.Daily() == date && !game.IsCompleted() {
+				returnToPlayState(game)
+				return
+			}
+
+			if slot, exist := findDailySlot(date); exist && game.LoadSlot(slot.ID) == nil {
+				returnToPlayState(game)
+				return
+			}
+
+			if game.StartDaily(today) == nil {
+				returnToPlayState(game)
+			}
+		}},
+		{"Back", func() {
+			game.PopState()
+		}},
+	}
+
+	return ds
+}
+
+// NewStatisticsState returns a new state that shows the statistics
+func NewStatisticsState(game Game) State {
+	return &statisticsState{Game: game}
+}
+
 // NewMenuState returns a new menu state
 func NewMenuState(game Game) State {
 	return &menuState{
@@ -44,8 +384,29 @@ func NewMenuState(game Game) State {
 				game.PopState()
 			}},
 			{"New Game", func() {
-				game.SetBoard(board.New(board.Medium))
-				game.PopState()
+				game.PushState(NewDifficultyMenuState(game))
+			}},
+			{"Daily", func() {
+				game.PushState(NewDailyState(game))
+			}},
+			{"Load Game", func() {
+				game.Save()
+				game.PushState(NewLoadGameMenuState(game))
+			}},
+			{"Import", func() {
+				game.PushState(NewInputState(game, "Puzzle File", "", 256, func(file string) error {
+					b, err := ImportPuzzle(file)
+					if err != nil {
+						return withoutPath(err)
+					}
+
+					game.SetBoard(b, board.Custom)
+					returnToPlayState(game)
+					return nil
+				}))
+			}},
+			{"Export", func() {
+				game.PushState(NewExportMenuState(game))
 			}},
 			{"Themes", func() {
 				themes, err := theme.GetThemes()
@@ -55,9 +416,14 @@ func NewMenuState(game Game) State {
 
 				game.PushState(NewThemesMenuState(game, themes))
 			}},
+			{"Statistics", func() {
+				game.PushState(NewStatisticsState(game))
+			}},
+			{"Settings", func() {
+				game.PushState(NewSettingsMenuState(game))
+			}},
 			{"Exit", func() {
 				game.Exit()
-
 			}},
 		},
 	}
@@ -82,3 +448,20 @@ func NewThemesMenuState(game Game, themes []theme.Theme) State {
 		Options: themeOptions,
 	}
 }
+
+// NewSettingsMenuState returns a menu state
+// that toggles the settings
+func NewSettingsMenuState(game Game) State {
+	ms := &menuState{Game: game}
+	ms.Options = []menuOption{
+		{getToggleTitle("Auto Remove Notes", game.Settings().AutoRemoveNotes), func() {
+			settings := game.Settings()
+			settings.AutoRemoveNotes = !settings.AutoRemoveNotes
+			game.SetSettings(settings)
+
+			ms.Options[0].title = getToggleTitle("Auto Remove Notes", settings.AutoRemoveNotes)
+		}},
+	}
+
+	return ms
+}
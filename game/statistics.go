@@ -0,0 +1,188 @@
+package game
+
+import (
+	"encoding/json"
+	"os"
+	"path"
+	"time"
+)
+
+// DifficultyStatistics are the records of games with the same difficulty
+type DifficultyStatistics struct {
+	Started       int   `json:"started"`
+	Won           int   `json:"won"`
+	Abandoned     int   `json:"abandoned"`
+	BestTimeMS    int64 `json:"best_time_ms"`
+	TotalTimeMS   int64 `json:"total_time_ms"`
+	CurrentStreak int   `json:"current_streak"`
+	BestStreak    int   `json:"best_streak"`
+}
+
+// BestTime returns the shortest time a game is won in
+func (ds DifficultyStatistics) BestTime() time.Duration {
+	return time.Duration(ds.BestTimeMS) * time.Millisecond
+}
+
+// AverageTime returns the average time of the won games
+func (ds DifficultyStatistics) AverageTime() time.Duration {
+	if ds.Won == 0 {
+		return 0
+	}
+	return time.Duration(ds.TotalTimeMS/int64(ds.Won)) * time.Millisecond
+}
+
+// DailyStatistics are the records of the daily puzzles
+type DailyStatistics struct {
+	// SolvedMS has the solve time of every solved daily puzzle by its date
+	SolvedMS   map[string]int64 `json:"solved_ms"`
+	LastSolved string           `json:"last_solved"`
+	Streak     int              `json:"streak"`
+	BestStreak int              `json:"best_streak"`
+}
+
+// SolveTime returns the solve time of the daily puzzle
+// of the given date and if it is solved
+func (ds DailyStatistics) SolveTime(date string) (time.Duration, bool) {
+	solvedMS, solved := ds.SolvedMS[date]
+	return time.Duration(solvedMS) * time.Millisecond, solved
+}
+
+// CurrentStreak returns the number of daily puzzles solved in a row,
+// the streak is broken if neither today's nor yesterday's puzzle is solved
+func (ds DailyStatistics) CurrentStreak(today time.Time) int {
+	if ds.LastSolved != formatDate(today) && ds.LastSolved != formatDate(today.AddDate(0, 0, -1)) {
+		return 0
+	}
+	return ds.Streak
+}
+
+type Statistics struct {
+	Difficulties map[byte]DifficultyStatistics `json:"difficulties"`
+	Daily        DailyStatistics               `json:"daily"`
+}
+
+// Get returns the statistics of the given difficulty
+func (stats Statistics) Get(difficulty byte) DifficultyStatistics {
+	return stats.Difficulties[difficulty]
+}
+
+// RecordStart records a started game
+func (stats *Statistics) RecordStart(difficulty byte) {
+	stats.update(difficulty, func(ds *DifficultyStatistics) {
+		ds.Started++
+	})
+}
+
+// RecordWin records a won game and its time
+func (stats *Statistics) RecordWin(difficulty byte, elapsed time.Duration) {
+	stats.update(difficulty, func(ds *DifficultyStatistics) {
+		ds.Won++
+		ds.TotalTimeMS += elapsed.Milliseconds()
+		if ds.BestTimeMS == 0 || elapsed.Milliseconds() < ds.BestTimeMS {
+			ds.BestTimeMS = elapsed.Milliseconds()
+		}
+
+		ds.CurrentStreak++
+		if ds.CurrentStreak > ds.BestStreak {
+			ds.BestStreak = ds.CurrentStreak
+		}
+	})
+}
+
+// RecordAbandon records an unfinished game whose save slot is deleted,
+// a game replaced by a new game stays in its slot and can still be won
+func (stats *Statistics) RecordAbandon(difficulty byte) {
+	stats.update(difficulty, func(ds *DifficultyStatistics) {
+		ds.Abandoned++
+		ds.CurrentStreak = 0
+	})
+}
+
+// RecordDaily records the solve time of the daily puzzle of the given date,
+// only the first solve of a daily puzzle is recorded
+func (stats *Statistics) RecordDaily(date string, elapsed time.Duration) {
+	daily := &stats.Daily
+	if _, solved := daily.SolvedMS[date]; solved {
+		return
+	}
+
+	if daily.SolvedMS == nil {
+		daily.SolvedMS = map[string]int64{}
+	}
+	daily.SolvedMS[date] = elapsed.Milliseconds()
+
+	// older puzzles do not change the streak
+	if date < daily.LastSolved {
+		return
+	}
+
+	previous, err := time.Parse(dateFormat, date)
+	if err == nil && daily.LastSolved == formatDate(previous.AddDate(0, 0, -1)) {
+		daily.Streak++
+	} else {
+		daily.Streak = 1
+	}
+	daily.LastSolved = date
+
+	if daily.Streak > daily.BestStreak {
+		daily.BestStreak = daily.Streak
+	}
+}
+
+func (stats *Statistics) update(difficulty byte, fn func(ds *DifficultyStatistics)) {
+	if stats.Difficulties == nil {
+		stats.Difficulties = map[byte]DifficultyStatistics{}
+	}
+
+	ds := stats.Difficulties[difficulty]
+	fn(&ds)
+	stats.Difficulties[difficulty] = ds
+}
+
+func getStatisticsFile() (string, error) {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+
+	return path.Join(configDir, "sudoku", "stats.json"), nil
+}
+
+func LoadStatistics() (Statistics, error) {
+	statisticsFile, err := getStatisticsFile()
+	if err != nil {
+		return Statistics{}, err
+	}
+
+	file, err := os.ReadFile(statisticsFile)
+	if err != nil {
+		return Statistics{}, err
+	}
+
+	stats := Statistics{}
+	err = json.Unmarshal(file, &stats)
+	if err != nil {
+		return Statistics{}, err
+	}
+
+	return stats, nil
+}
+
+func SaveStatistics(stats Statistics) error {
+	statisticsFile, err := getStatisticsFile()
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(path.Dir(statisticsFile), os.ModePerm)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(stats)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(statisticsFile, data, os.ModePerm)
+}
@@ -0,0 +1,83 @@
+package game
+
+import (
+	"fmt"
+
+	"github.com/serhatsdev/sudoku/game/ui"
+)
+
+// statisticsState shows the statistics of a difficulty,
+// left and right arrows switch between difficulties
+type statisticsState struct {
+	Game Game
+	Pos  int
+}
+
+func (ss *statisticsState) OnResize(width, height int) {
+	if width < ss.Game.MinWidth() || height < ss.Game.MinHeight() {
+		ss.Game.PushState(NewSmallSizeState(ss.Game, width, height))
+	}
+}
+
+func (ss *statisticsState) OnKeyPress(key string) {
+	count := len(difficulties)
+
+	if key == "esc" || key == "enter" {
+		ss.Game.PopState()
+	} else if key == "arrow_left" {
+		ss.Pos = (count + ss.Pos - 1) % count
+	} else if key == "arrow_right" {
+		ss.Pos = (ss.Pos + 1) % count
+	}
+}
+
+// OnMouse switches between difficulties with the wheel
+func (ss *statisticsState) OnMouse(x, y int, button string) {
+	if button == "wheel_up" {
+		ss.OnKeyPress("arrow_left")
+	} else if button == "wheel_down" {
+		ss.OnKeyPress("arrow_right")
+	}
+}
+
+func (ss *statisticsState) Draw() {
+	difficulty := difficulties[ss.Pos]
+	ds := ss.Game.Statistics().Get(difficulty)
+
+	best, average := "-", "-"
+	if ds.Won > 0 {
+		best = formatDuration(ds.BestTime())
+		average = formatDuration(ds.AverageTime())
+	}
+
+	ss.Game.Client().DrawCenter(&ui.BoxWidget{
+		Child: &ui.ColumnWidget{
+			Children: []ui.Widget{
+				&ui.TextWidget{
+					String: fmt.Sprintf("< %s >", DifficultyName(difficulty)),
+					Color:  ss.Game.Theme().MenuCursor,
+				},
+				&ui.StatusWidget{
+					Items: []ui.StatusItem{
+						{Label: "Played", Value: fmt.Sprint(ds.Started)},
+						{Label: "Won", Value: fmt.Sprint(ds.Won)},
+						{Label: "Abandoned", Value: fmt.Sprint(ds.Abandoned)},
+						{Label: "Best Time", Value: best},
+						{Label: "Average Time", Value: average},
+						{Label: "Streak", Value: fmt.Sprint(ds.CurrentStreak)},
+						{Label: "Best Streak", Value: fmt.Sprint(ds.BestStreak)},
+					},
+					Color: ss.Game.Theme().Menu,
+				},
+			},
+			Spacing: 1,
+			HAlign:  ui.HAlignCenter,
+		},
+		Fill:          true,
+		PaddingTop:    1,
+		PaddingBottom: 1,
+		PaddingLeft:   2,
+		PaddingRight:  2,
+		Color:         ss.Game.Theme().MenuBox,
+	})
+}
@@ -27,21 +27,28 @@ type BoardCellsTheme struct {
 	Predefined ColorPair `json:"predefined"`
 	Conflict   ColorPair `json:"conflict"`
 	Wrong      ColorPair `json:"wrong"`
+	Notes      ColorPair `json:"notes"`
 }
 
 type BoardTheme struct {
-	Cursor string          `json:"cursor"`
-	Border ColorPair       `json:"border"`
-	Cells  BoardCellsTheme `json:"cells"`
+	Cursor    string          `json:"cursor"`
+	Highlight string          `json:"highlight"`
+	Border    ColorPair       `json:"border"`
+	Cells     BoardCellsTheme `json:"cells"`
 }
 
 var loadedThemes []Theme
 
+// baseThemeFile is the default theme the fields missing in the theme files
+// are taken from, the files written by older versions miss the newer fields
+const baseThemeFile = "default_light.json"
+
 func getDefaultThemes() map[string]Theme {
 	defaultLight := Theme{
 		Name: "Default Light",
 		Board: BoardTheme{
-			Cursor: "#f9da75",
+			Cursor:    "#f9da75",
+			Highlight: "#dce6f2",
 			Border: ColorPair{
 				FG: "#344861",
 				BG: "#ffffff",
@@ -63,6 +70,10 @@ func getDefaultThemes() map[string]Theme {
 					FG: "#fb3d3f",
 					BG: "#ffffff",
 				},
+				Notes: ColorPair{
+					FG: "#6e7c8c",
+					BG: "#ffffff",
+				},
 			},
 		},
 		Menu: ColorPair{
@@ -88,7 +99,7 @@ func getDefaultThemes() map[string]Theme {
 	}
 
 	return map[string]Theme{
-		"default_light.json": defaultLight,
+		baseThemeFile: defaultLight,
 	}
 }
 
@@ -162,7 +173,7 @@ func loadThemes() ([]Theme, error) {
 			return nil, err
 		}
 
-		theme := Theme{}
+		theme := getDefaultThemes()[baseThemeFile]
 		err = json.Unmarshal([]byte(data), &theme)
 		if err != nil {
 			return nil, err
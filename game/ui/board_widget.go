@@ -1,37 +1,68 @@
 package ui
 
 import (
+	"strconv"
+
 	"github.com/serhatsdev/sudoku/game/board"
 	"github.com/serhatsdev/sudoku/game/theme"
 )
 
-// BoardHeight is height of BoardOutline
-var BoardHeight = len(boardOutline)
-
-// BoardWidth is width of BoardOutline
-var BoardWidth = len(boardOutline[0])
-
-// boardOutline for board widget
-var boardOutline = [][]rune{
-	[]rune("┏━━━┯━━━┯━━━┳━━━┯━━━┯━━━┳━━━┯━━━┯━━━┓"),
-	[]rune("┃   │   │   ┃   │   │   ┃   │   │   ┃"),
-	[]rune("┠───┼───┼───╂───┼───┼───╂───┼───┼───┨"),
-	[]rune("┃   │   │   ┃   │   │   ┃   │   │   ┃"),
-	[]rune("┠───┼───┼───╂───┼───┼───╂───┼───┼───┨"),
-	[]rune("┃   │   │   ┃   │   │   ┃   │   │   ┃"),
-	[]rune("┣━━━┿━━━┿━━━╋━━━┿━━━┿━━━╋━━━┿━━━┿━━━┫"),
-	[]rune("┃   │   │   ┃   │   │   ┃   │   │   ┃"),
-	[]rune("┠───┼───┼───╂───┼───┼───╂───┼───┼───┨"),
-	[]rune("┃   │   │   ┃   │   │   ┃   │   │   ┃"),
-	[]rune("┠───┼───┼───╂───┼───┼───╂───┼───┼───┨"),
-	[]rune("┃   │   │   ┃   │   │   ┃   │   │   ┃"),
-	[]rune("┣━━━┿━━━┿━━━╋━━━┿━━━┿━━━╋━━━┿━━━┿━━━┫"),
-	[]rune("┃   │   │   ┃   │   │   ┃   │   │   ┃"),
-	[]rune("┠───┼───┼───╂───┼───┼───╂───┼───┼───┨"),
-	[]rune("┃   │   │   ┃   │   │   ┃   │   │   ┃"),
-	[]rune("┠───┼───┼───╂───┼───┼───╂───┼───┼───┨"),
-	[]rune("┃   │   │   ┃   │   │   ┃   │   │   ┃"),
-	[]rune("┗━━━┷━━━┷━━━┻━━━┷━━━┷━━━┻━━━┷━━━┷━━━┛"),
+// BoardWidth and BoardHeight are the sizes of the compact 9x9 board
+var BoardWidth, BoardHeight = GetBoardSize(board.Classic, false)
+
+// Line weights of the board outline
+const (
+	noLine = iota
+	lightLine
+	heavyLine
+)
+
+// junctions are the outline characters where the lines meet,
+// the keys are the weights of the up, down, left and right lines
+var junctions = map[[4]int]rune{
+	{0, 1, 0, 1}: '┌', {0, 1, 0, 2}: '┍', {0, 2, 0, 1}: '┎', {0, 2, 0, 2}: '┏',
+	{0, 1, 1, 0}: '┐', {0, 1, 2, 0}: '┑', {0, 2, 1, 0}: '┒', {0, 2, 2, 0}: '┓',
+	{1, 0, 0, 1}: '└', {1, 0, 0, 2}: '┕', {2, 0, 0, 1}: '┖', {2, 0, 0, 2}: '┗',
+	{1, 0, 1, 0}: '┘', {1, 0, 2, 0}: '┙', {2, 0, 1, 0}: '┚', {2, 0, 2, 0}: '┛',
+
+	{1, 1, 0, 1}: '├', {1, 1, 0, 2}: '┝', {2, 1, 0, 1}: '┞', {1, 2, 0, 1}: '┟',
+	{2, 2, 0, 1}: '┠', {2, 1, 0, 2}: '┡', {1, 2, 0, 2}: '┢', {2, 2, 0, 2}: '┣',
+	{1, 1, 1, 0}: '┤', {1, 1, 2, 0}: '┥', {2, 1, 1, 0}: '┦', {1, 2, 1, 0}: '┧',
+	{2, 2, 1, 0}: '┨', {2, 1, 2, 0}: '┩', {1, 2, 2, 0}: '┪', {2, 2, 2, 0}: '┫',
+	{0, 1, 1, 1}: '┬', {0, 1, 2, 1}: '┭', {0, 1, 1, 2}: '┮', {0, 1, 2, 2}: '┯',
+	{0, 2, 1, 1}: '┰', {0, 2, 2, 1}: '┱', {0, 2, 1, 2}: '┲', {0, 2, 2, 2}: '┳',
+	{1, 0, 1, 1}: '┴', {1, 0, 2, 1}: '┵', {1, 0, 1, 2}: '┶', {1, 0, 2, 2}: '┷',
+	{2, 0, 1, 1}: '┸', {2, 0, 2, 1}: '┹', {2, 0, 1, 2}: '┺', {2, 0, 2, 2}: '┻',
+
+	{1, 1, 1, 1}: '┼', {1, 1, 2, 1}: '┽', {1, 1, 1, 2}: '┾', {1, 1, 2, 2}: '┿',
+	{2, 1, 1, 1}: '╀', {1, 2, 1, 1}: '╁', {2, 2, 1, 1}: '╂', {2, 1, 2, 1}: '╃',
+	{2, 1, 1, 2}: '╄', {1, 2, 2, 1}: '╅', {1, 2, 1, 2}: '╆', {2, 1, 2, 2}: '╇',
+	{1, 2, 2, 2}: '╈', {2, 2, 2, 1}: '╉', {2, 2, 1, 2}: '╊', {2, 2, 2, 2}: '╋',
+}
+
+// horizontalLines and verticalLines are the outline characters by line weight,
+// the lines between the cells of the same killer cage are dashed
+var (
+	horizontalLines       = [3]rune{' ', '─', '━'}
+	verticalLines         = [3]rune{' ', '│', '┃'}
+	dashedHorizontalLines = [3]rune{' ', '┄', '┅'}
+	dashedVerticalLines   = [3]rune{' ', '┆', '┇'}
+)
+
+// GetBoardSize returns the width and height of a board with the given
+// dimensions, the large board shows the notes in the cells
+func GetBoardSize(dims board.Dimensions, large bool) (int, int) {
+	cellWidth, cellHeight := getCellSize(dims, large)
+	return dims.Size()*(cellWidth+1) + 1, dims.Size()*(cellHeight+1) + 1
+}
+
+// getCellSize returns the size of the cells, the large cells show
+// the notes in a grid with a note column for every column of a box
+func getCellSize(dims board.Dimensions, large bool) (int, int) {
+	if large {
+		return dims.BoxWidth*2 - 1, dims.BoxHeight
+	}
+	return 3, 1
 }
 
 // BoardWidget is an ui widget for sudoku board representation
@@ -39,77 +70,300 @@ type BoardWidget struct {
 	Board     board.Board
 	CursorPos board.Point2
 	Theme     theme.BoardTheme
+
+	// Highlights are the cells drawn with the highlight color
+	Highlights map[board.Point2]struct{}
+
+	// Large draws the board with larger cells
+	// that show the notes as a 3x3 grid
+	Large bool
+
+	// x and y are the position the board is last drawn at
+	x, y int
 }
 
 // Draw draws the board widget to the terminal
 func (bw *BoardWidget) Draw(context Context, x, y int) {
+	bw.x, bw.y = x, y
 	bw.drawBorders(context, x, y)
+	bw.drawCageSums(context, x, y)
 	bw.drawCells(context, x, y)
 }
 
 // Width returns the width of the board widget
 func (bw *BoardWidget) Width() int {
-	return BoardWidth
+	width, _ := GetBoardSize(bw.Board.Dimensions(), bw.Large)
+	return width
 }
 
 // Height returns the height of the board widget
 func (bw *BoardWidget) Height() int {
-	return BoardHeight
+	_, height := GetBoardSize(bw.Board.Dimensions(), bw.Large)
+	return height
+}
+
+func (bw *BoardWidget) getCellSize() (int, int) {
+	return getCellSize(bw.Board.Dimensions(), bw.Large)
+}
+
+// getLineWeight returns the weight of the line between the given neighbour
+// cells, the lines between the boxes and the jigsaw regions are heavy
+func (bw *BoardWidget) getLineWeight(a, b board.Point2) int {
+	if !bw.isOnBoard(a) || !bw.isOnBoard(b) || bw.Board.GetRegion(a) != bw.Board.GetRegion(b) {
+		return heavyLine
+	}
+	return lightLine
+}
+
+// isInSameCage returns if the given cells are in the same killer cage
+func (bw *BoardWidget) isInSameCage(a, b board.Point2) bool {
+	if !bw.isOnBoard(a) || !bw.isOnBoard(b) {
+		return false
+	}
+
+	cageA, existA := bw.Board.GetCage(a)
+	cageB, existB := bw.Board.GetCage(b)
+	return existA && existB && cageA.Cells[0] == cageB.Cells[0]
+}
+
+func (bw *BoardWidget) isOnBoard(pos board.Point2) bool {
+	size := bw.Board.Dimensions().Size()
+	return pos.X >= 0 && pos.Y >= 0 && pos.X < size && pos.Y < size
+}
+
+// getJunction returns the outline character of the corner
+// at the top left of the cell at the given position
+func (bw *BoardWidget) getJunction(pos board.Point2) rune {
+	size := bw.Board.Dimensions().Size()
+	up, down, left, right := noLine, noLine, noLine, noLine
+
+	upLeft, upRight := board.Point2{X: pos.X - 1, Y: pos.Y - 1}, board.Point2{X: pos.X, Y: pos.Y - 1}
+	downLeft, downRight := board.Point2{X: pos.X - 1, Y: pos.Y}, pos
+
+	if pos.Y > 0 {
+		up = bw.getLineWeight(upLeft, upRight)
+	}
+	if pos.Y < size {
+		down = bw.getLineWeight(downLeft, downRight)
+	}
+	if pos.X > 0 {
+		left = bw.getLineWeight(upLeft, downLeft)
+	}
+	if pos.X < size {
+		right = bw.getLineWeight(upRight, downRight)
+	}
+
+	return junctions[[4]int{up, down, left, right}]
 }
 
 func (bw *BoardWidget) drawBorders(context Context, x, y int) {
+	size := bw.Board.Dimensions().Size()
+	cellWidth, cellHeight := bw.getCellSize()
+
 	context.StyleFG(bw.Theme.Border.FG)
 	context.StyleBG(bw.Theme.Border.BG)
 
-	// Horizontal lines
-	for i := 0; i < bw.Height(); i += 2 {
-		for j := 0; j < bw.Width(); j++ {
-			context.SetContent(x+j, y+i, boardOutline[i][j])
+	for i := 0; i <= size; i++ {
+		lineY := y + i*(cellHeight+1)
+
+		for j := 0; j <= size; j++ {
+			pos := board.Point2{X: j, Y: i}
+			lineX := x + j*(cellWidth+1)
+			context.SetContent(lineX, lineY, bw.getJunction(pos))
+
+			// horizontal line at the top of the cell
+			if j < size {
+				above := board.Point2{X: j, Y: i - 1}
+				lines := horizontalLines
+				if bw.isInSameCage(above, pos) {
+					lines = dashedHorizontalLines
+				}
+
+				weight := bw.getLineWeight(above, pos)
+				for k := 1; k <= cellWidth; k++ {
+					context.SetContent(lineX+k, lineY, lines[weight])
+				}
+			}
+
+			// vertical line at the left of the cell
+			if i < size {
+				left := board.Point2{X: j - 1, Y: i}
+				lines := verticalLines
+				if bw.isInSameCage(left, pos) {
+					lines = dashedVerticalLines
+				}
+
+				weight := bw.getLineWeight(left, pos)
+				for k := 1; k <= cellHeight; k++ {
+					context.SetContent(lineX, lineY+k, lines[weight])
+				}
+			}
+		}
+	}
+}
+
+// drawCageSums draws the sums of the killer cages
+// on the line above the top left cell of the cages
+func (bw *BoardWidget) drawCageSums(context Context, x, y int) {
+	for _, cage := range bw.Board.Cages() {
+		topLeft := cage.Cells[0]
+		for _, pos := range cage.Cells {
+			if pos.Y < topLeft.Y || (pos.Y == topLeft.Y && pos.X < topLeft.X) {
+				topLeft = pos
+			}
 		}
+
+		cx, cy := bw.gridToScreen(topLeft)
+		for i, char := range strconv.Itoa(cage.Sum) {
+			context.SetContent(x+cx+i, y+cy-1, char)
+		}
+	}
+}
+
+// Markers of the cells of the variant rules, the left marker shows the
+// regions of the cell and the right marker shows the parity of the cell
+const (
+	diagonalMarker     = '╲'
+	antiDiagonalMarker = '╱'
+	bothDiagonalMarker = '╳'
+	windowMarker       = '∘'
+	evenMarker         = '□'
+	oddMarker          = '○'
+)
+
+// getMarkers returns the left and right markers
+// of the cells by the constraints of the board
+func (bw *BoardWidget) getMarkers() map[board.Point2][2]rune {
+	dims := bw.Board.Dimensions()
+	size := dims.Size()
+	markers := map[board.Point2][2]rune{}
+	setMarker := func(pos board.Point2, side int, marker rune) {
+		cellMarkers := markers[pos]
+		cellMarkers[side] = marker
+		markers[pos] = cellMarkers
 	}
 
-	// Vertical lines
-	for i := 1; i < bw.Height(); i += 2 {
-		for j := 0; j < bw.Width(); j += 4 {
-			context.SetContent(x+j, y+i, boardOutline[i][j])
+	for _, constraint := range bw.Board.Constraints() {
+		switch constraint := constraint.(type) {
+		case board.Diagonals:
+			for i := 0; i < size; i++ {
+				setMarker(board.Point2{X: i, Y: i}, 0, diagonalMarker)
+				setMarker(board.Point2{X: size - 1 - i, Y: i}, 0, antiDiagonalMarker)
+			}
+			if size%2 == 1 {
+				setMarker(board.Point2{X: size / 2, Y: size / 2}, 0, bothDiagonalMarker)
+			}
+		case board.Windoku:
+			for _, window := range constraint.Windows(dims) {
+				for _, pos := range window {
+					if markers[pos][0] == 0 {
+						setMarker(pos, 0, windowMarker)
+					}
+				}
+			}
+		case board.EvenOdd:
+			for _, pos := range constraint.Even {
+				setMarker(pos, 1, evenMarker)
+			}
+			for _, pos := range constraint.Odd {
+				setMarker(pos, 1, oddMarker)
+			}
 		}
 	}
+	return markers
 }
 
 func (bw *BoardWidget) drawCells(context Context, x, y int) {
 	styles := bw.getCellStyles()
+	markers := bw.getMarkers()
+	cellWidth, cellHeight := bw.getCellSize()
 
-	for i := 0; i < board.Size; i++ {
-		for j := 0; j < board.Size; j++ {
+	for i := 0; i < bw.Board.Dimensions().Size(); i++ {
+		for j := 0; j < bw.Board.Dimensions().Size(); j++ {
 			pos := board.Point2{X: j, Y: i}
 			cx, cy := bw.gridToScreen(pos)
-			char := bw.getCellRune(pos)
 			style := *styles[i][j]
 
 			context.StyleFG(style.FG)
 			context.StyleBG(style.BG)
 
-			context.SetContent(x+cx, y+cy, ' ')
-			context.SetContent(x+cx+1, y+cy, char)
-			context.SetContent(x+cx+2, y+cy, ' ')
+			for k := 0; k < cellHeight; k++ {
+				for l := 0; l < cellWidth; l++ {
+					context.SetContent(x+cx+l, y+cy+k, ' ')
+				}
+			}
+
+			if bw.Board.Get(pos) != 0 {
+				char := board.Symbol(bw.Board.Get(pos))
+				context.SetContent(x+cx+cellWidth/2, y+cy+cellHeight/2, char)
+			} else if len(bw.Board.GetNotes(pos)) > 0 {
+				context.StyleFG(bw.getNotesColor())
+				bw.drawNotes(context, pos, x+cx, y+cy)
+			}
+
+			// the notes of the large cells use the corners
+			if cellMarkers, exist := markers[pos]; exist && (!bw.Large || len(bw.Board.GetNotes(pos)) == 0) {
+				context.StyleFG(bw.getNotesColor())
+				for side, marker := range cellMarkers {
+					if marker != 0 {
+						context.SetContent(x+cx+side*(cellWidth-1), y+cy+cellHeight-1, marker)
+					}
+				}
+			}
 		}
 	}
 }
 
+// drawNotes draws the notes of the cell in the shape of a box
+// on the large board, the compact board only shows that the cell has notes
+func (bw *BoardWidget) drawNotes(context Context, pos board.Point2, x, y int) {
+	if !bw.Large {
+		context.SetContent(x+1, y, '·')
+		return
+	}
+
+	boxWidth := bw.Board.Dimensions().BoxWidth
+	for _, note := range bw.Board.GetNotes(pos) {
+		row := (note - 1) / boxWidth
+		column := (note - 1) % boxWidth
+		context.SetContent(x+column*2, y+row, board.Symbol(note))
+	}
+}
+
+// getNotesColor returns the notes color,
+// themes without notes color use the normal color
+func (bw *BoardWidget) getNotesColor() string {
+	if bw.Theme.Cells.Notes.FG == "" {
+		return bw.Theme.Cells.Normal.FG
+	}
+	return bw.Theme.Cells.Notes.FG
+}
+
 func (bw *BoardWidget) gridToScreen(pos board.Point2) (int, int) {
-	return pos.X*4 + 1, pos.Y*2 + 1
+	cellWidth, cellHeight := bw.getCellSize()
+	return pos.X*(cellWidth+1) + 1, pos.Y*(cellHeight+1) + 1
 }
 
-func (bw *BoardWidget) getCellRune(pos board.Point2) rune {
-	if bw.Board.Get(pos) == 0 {
-		return ' '
+// ScreenToGrid returns the position of the cell at the given screen position
+// of the last drawn board, the inverse of gridToScreen. It returns false
+// for the lines between the cells and the positions out of the board.
+func (bw *BoardWidget) ScreenToGrid(x, y int) (board.Point2, bool) {
+	cellWidth, cellHeight := bw.getCellSize()
+	x, y = x-bw.x-1, y-bw.y-1
+	if x < 0 || y < 0 || x%(cellWidth+1) == cellWidth || y%(cellHeight+1) == cellHeight {
+		return board.Point2{}, false
 	}
 
-	return '0' + rune(bw.Board.Get(pos))
+	pos := board.Point2{X: x / (cellWidth + 1), Y: y / (cellHeight + 1)}
+	return pos, bw.isOnBoard(pos)
 }
 
-func (bw *BoardWidget) getCellStyles() [board.Size][board.Size]*theme.ColorPair {
-	styles := [board.Size][board.Size]*theme.ColorPair{}
+func (bw *BoardWidget) getCellStyles() [][]*theme.ColorPair {
+	size := bw.Board.Dimensions().Size()
+	styles := make([][]*theme.ColorPair, size)
+	for i := range styles {
+		styles[i] = make([]*theme.ColorPair, size)
+	}
 
 	// Set conflict style
 	value := bw.Board.Get(bw.CursorPos)
@@ -120,8 +374,8 @@ func (bw *BoardWidget) getCellStyles() [board.Size][board.Size]*theme.ColorPair
 	}
 
 	// Set other styles
-	for i := 0; i < board.Size; i++ {
-		for j := 0; j < board.Size; j++ {
+	for i := 0; i < size; i++ {
+		for j := 0; j < size; j++ {
 			if styles[i][j] != nil {
 				continue
 			}
@@ -137,6 +391,16 @@ func (bw *BoardWidget) getCellStyles() [board.Size][board.Size]*theme.ColorPair
 		}
 	}
 
+	// Set highlight style
+	if bw.Theme.Highlight != "" {
+		for pos := range bw.Highlights {
+			styles[pos.Y][pos.X] = &theme.ColorPair{
+				FG: styles[pos.Y][pos.X].FG,
+				BG: bw.Theme.Highlight,
+			}
+		}
+	}
+
 	// Set cursor style
 	styles[bw.CursorPos.Y][bw.CursorPos.X] = &theme.ColorPair{
 		FG: styles[bw.CursorPos.Y][bw.CursorPos.X].FG,
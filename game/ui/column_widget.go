@@ -0,0 +1,50 @@
+package ui
+
+// ColumnWidget is an ui widget that draws its children one under another
+type ColumnWidget struct {
+	Children []Widget
+
+	// Spacing is the number of empty lines between children
+	Spacing int
+	HAlign  byte
+}
+
+// Draw draws the children to the terminal
+func (cw *ColumnWidget) Draw(context Context, x, y int) {
+	width := cw.Width()
+	for _, child := range cw.Children {
+		childX := x
+		if cw.HAlign == HAlignCenter {
+			childX += (width - child.Width()) / 2
+		} else if cw.HAlign == HAlignEnd {
+			childX += width - child.Width()
+		}
+
+		child.Draw(context, childX, y)
+		y += child.Height() + cw.Spacing
+	}
+}
+
+// Width returns the width of the widest child
+func (cw *ColumnWidget) Width() int {
+	width := 0
+	for _, child := range cw.Children {
+		if child.Width() > width {
+			width = child.Width()
+		}
+	}
+	return width
+}
+
+// Height returns the total height of the children and spacing
+func (cw *ColumnWidget) Height() int {
+	if len(cw.Children) == 0 {
+		return 0
+	}
+
+	height := cw.Spacing * (len(cw.Children) - 1)
+	for _, child := range cw.Children {
+		height += child.Height()
+	}
+	return height
+}
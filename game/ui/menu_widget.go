@@ -13,18 +13,25 @@ type MenuWidget struct {
 
 	HAlign   byte
 	MinWidth int
+	// MaxHeight limits the visible options, the options
+	// around the cursor are shown. Zero means no limit.
+	MaxHeight int
 
 	Color  theme.ColorPair
 	Cursor theme.ColorPair
 
 	width int
+	// x and y are the position the menu is last drawn at
+	x, y int
 }
 
 // Draw draws the menu widget to the terminal
 func (mw *MenuWidget) Draw(context Context, x, y int) {
-	for i, option := range mw.Options {
-		fg, bg := mw.getStyleForOption(i)
-		option = mw.formatOption(option)
+	mw.x, mw.y = x, y
+	first := mw.getFirstVisible()
+	for i := 0; i < mw.Height(); i++ {
+		fg, bg := mw.getStyleForOption(first + i)
+		option := mw.formatOption(mw.Options[first+i])
 
 		context.StyleFG(fg)
 		context.StyleBG(bg)
@@ -50,9 +57,34 @@ func (mw *MenuWidget) Width() int {
 
 // Height returns the height of the menu widget
 func (mw *MenuWidget) Height() int {
+	if mw.MaxHeight > 0 && len(mw.Options) > mw.MaxHeight {
+		return mw.MaxHeight
+	}
 	return len(mw.Options)
 }
 
+// OptionAt returns the index of the option at the given screen
+// position of the last drawn menu and if there is an option
+func (mw *MenuWidget) OptionAt(x, y int) (int, bool) {
+	if x < mw.x || y < mw.y || x >= mw.x+mw.Width() || y >= mw.y+mw.Height() {
+		return 0, false
+	}
+	return mw.getFirstVisible() + y - mw.y, true
+}
+
+// getFirstVisible returns the index of the first
+// visible option, keeping the cursor in the middle
+func (mw *MenuWidget) getFirstVisible() int {
+	first := mw.CursorIndex - mw.Height()/2
+	if first > len(mw.Options)-mw.Height() {
+		first = len(mw.Options) - mw.Height()
+	}
+	if first < 0 {
+		first = 0
+	}
+	return first
+}
+
 func (mw *MenuWidget) getStyleForOption(index int) (string, string) {
 	if mw.CursorIndex == index {
 		return mw.Cursor.FG, mw.Cursor.BG
@@ -0,0 +1,61 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/serhatsdev/sudoku/game/theme"
+)
+
+// StatusItem is a labeled value shown in the status widget
+type StatusItem struct {
+	Label string
+	Value string
+}
+
+// StatusWidget is an ui widget that shows labeled values line by line
+type StatusWidget struct {
+	Items []StatusItem
+
+	Color theme.ColorPair
+}
+
+// Draw draws the status items to the terminal
+func (sw *StatusWidget) Draw(context Context, x, y int) {
+	context.StyleFG(sw.Color.FG)
+	context.StyleBG(sw.Color.BG)
+
+	labelWidth := sw.getLabelWidth()
+	for i, item := range sw.Items {
+		line := fmt.Sprintf("%-*v %v", labelWidth, item.Label+":", item.Value)
+		for j, char := range []rune(line) {
+			context.SetContent(x+j, y+i, char)
+		}
+	}
+}
+
+// Width returns the width of the longest status line
+func (sw *StatusWidget) Width() int {
+	width := 0
+	labelWidth := sw.getLabelWidth()
+	for _, item := range sw.Items {
+		if labelWidth+1+len([]rune(item.Value)) > width {
+			width = labelWidth + 1 + len([]rune(item.Value))
+		}
+	}
+	return width
+}
+
+// Height returns the number of status items
+func (sw *StatusWidget) Height() int {
+	return len(sw.Items)
+}
+
+func (sw *StatusWidget) getLabelWidth() int {
+	width := 0
+	for _, item := range sw.Items {
+		if len([]rune(item.Label))+1 > width {
+			width = len([]rune(item.Label)) + 1
+		}
+	}
+	return width
+}
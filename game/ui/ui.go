@@ -22,6 +22,11 @@ type Client interface {
 	OnResize(func(width, height int))
 	// OnKeyPress takes a function to run when a key pressed
 	OnKeyPress(func(key string))
+	// OnMouse takes a function to run when a mouse button pressed
+	// or the wheel scrolled at the given screen position
+	OnMouse(func(x, y int, button string))
+	// OnTick takes a function to run every second
+	OnTick(func())
 
 	// Draw draws the given widget at the given position
 	Draw(x, y int, widget Widget)
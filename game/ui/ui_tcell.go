@@ -1,9 +1,14 @@
 package ui
 
 import (
+	"time"
+
 	"github.com/gdamore/tcell/v2"
 )
 
+// tickInterval is the interval of the tick events
+const tickInterval = time.Second
+
 func NewTCellClient() (Client, error) {
 	screen, err := tcell.NewScreen()
 	if err != nil {
@@ -17,6 +22,7 @@ func NewTCellClient() (Client, error) {
 
 	client := tcellClient{
 		context: context,
+		done:    make(chan struct{}),
 	}
 
 	return &client, nil
@@ -29,13 +35,32 @@ var mapKeys = map[tcell.Key]string{
 	tcell.KeyRight: "arrow_right",
 	tcell.KeyEnter: "enter",
 	tcell.KeyESC:   "esc",
-	tcell.KeyCtrlZ: "ctrl+z",
+
+	tcell.KeyBackspace:  "backspace",
+	tcell.KeyBackspace2: "backspace",
+	tcell.KeyDelete:     "delete",
+	tcell.KeyCtrlZ:      "ctrl+z",
 }
 
+var mapButtons = map[tcell.ButtonMask]string{
+	tcell.Button1:   "left",
+	tcell.Button2:   "right",
+	tcell.WheelUp:   "wheel_up",
+	tcell.WheelDown: "wheel_down",
+}
+
+// wheelButtons are reported once for every scroll, they have no release
+const wheelButtons = tcell.WheelUp | tcell.WheelDown | tcell.WheelLeft | tcell.WheelRight
+
 type tcellClient struct {
 	context    tcellContext
 	onResize   func(width, height int)
 	onKeyPress func(key string)
+	onMouse    func(x, y int, button string)
+	onTick     func()
+	done       chan struct{}
+	// buttons are the mouse buttons held down
+	buttons tcell.ButtonMask
 }
 
 func (tc *tcellClient) Start() error {
@@ -43,6 +68,9 @@ func (tc *tcellClient) Start() error {
 	if err != nil {
 		return err
 	}
+	tc.context.screen.EnableMouse()
+
+	go tc.postTicks()
 
 EventLoop:
 	for {
@@ -51,6 +79,12 @@ EventLoop:
 			tc.onResize(event.Size())
 		case *tcell.EventKey:
 			tc.onKeyPress(getGameKey(event))
+		case *tcell.EventMouse:
+			tc.handleMouse(event)
+		case *tcell.EventInterrupt:
+			if tc.onTick != nil {
+				tc.onTick()
+			}
 		case nil:
 			break EventLoop
 		}
@@ -59,10 +93,45 @@ EventLoop:
 	return nil
 }
 
+// handleMouse runs the mouse function for the pressed buttons,
+// the buttons held down while the mouse moves are not pressed again
+func (tc *tcellClient) handleMouse(event *tcell.EventMouse) {
+	buttons := event.Buttons()
+	pressed := buttons &^ tc.buttons
+	tc.buttons = buttons &^ wheelButtons
+
+	if tc.onMouse == nil {
+		return
+	}
+	x, y := event.Position()
+	for mask, button := range mapButtons {
+		if pressed&mask != 0 {
+			tc.onMouse(x, y, button)
+		}
+	}
+}
+
 func (tc *tcellClient) Stop() {
+	close(tc.done)
 	tc.context.screen.Fini()
 }
 
+// postTicks posts an interrupt event every tick
+// to the event loop until the client is stopped
+func (tc *tcellClient) postTicks() {
+	ticker := time.NewTicker(tickInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			tc.context.screen.PostEvent(tcell.NewEventInterrupt(nil))
+		case <-tc.done:
+			return
+		}
+	}
+}
+
 func (tc *tcellClient) Size() (int, int) {
 	return tc.context.screen.Size()
 }
@@ -75,6 +144,14 @@ func (tc *tcellClient) OnKeyPress(fn func(key string)) {
 	tc.onKeyPress = fn
 }
 
+func (tc *tcellClient) OnMouse(fn func(x, y int, button string)) {
+	tc.onMouse = fn
+}
+
+func (tc *tcellClient) OnTick(fn func()) {
+	tc.onTick = fn
+}
+
 func (tc *tcellClient) Draw(x, y int, widget Widget) {
 	widget.Draw(tc.Context(), x, y)
 	tc.context.Show()
@@ -1,25 +1,98 @@
 package main
 
 import (
+	"flag"
 	"fmt"
+	"os"
 
 	"github.com/serhatsdev/sudoku/game"
+	"github.com/serhatsdev/sudoku/game/board"
 	"github.com/serhatsdev/sudoku/game/ui"
 )
 
 func main() {
+	subcommands := map[string]func(args []string) error{
+		"export":  runExport,
+		"booklet": runBooklet,
+	}
+	if len(os.Args) > 1 {
+		if run, exist := subcommands[os.Args[1]]; exist {
+			err := run(os.Args[2:])
+			if err != nil {
+				fmt.Println("error:", err.Error())
+				os.Exit(1)
+			}
+			return
+		}
+	}
+
+	puzzle := flag.String("puzzle", "", "play the given puzzle, 81 digits with '.' or '0' for the empty cells")
+	file := flag.String("file", "", "play the puzzle in the given file (.sdk, .ss or a text grid)")
+	id := flag.String("id", "", "play the generated puzzle with the given puzzle id")
+	flag.Parse()
+
+	var puzzleID *board.PuzzleID
+	if *id != "" {
+		parsedID, err := board.ParsePuzzleID(*id)
+		if err != nil {
+			fmt.Println("error:", err.Error())
+			os.Exit(1)
+		}
+		// the rules of the id are checked before the screen is taken
+		dims, _ := board.DimensionsOf(parsedID.Size)
+		if err := board.ValidateRules(dims, parsedID.Rules); err != nil {
+			fmt.Println("error:", err.Error())
+			os.Exit(1)
+		}
+		puzzleID = &parsedID
+	}
+
+	imported, err := importPuzzle(*puzzle, *file)
+	if err != nil {
+		fmt.Println("error:", err.Error())
+		os.Exit(1)
+	}
+
+	// the given puzzle replaces the auto-started one
+	var start func(game.Game) error
+	if imported != nil {
+		start = func(g game.Game) error {
+			g.SetBoard(imported, board.Custom)
+			return nil
+		}
+	} else if puzzleID != nil {
+		start = func(g game.Game) error {
+			return g.StartPuzzle(*puzzleID)
+		}
+	}
+
 	client, err := ui.NewTCellClient()
 	checkErr(err)
 
-	game, err := game.NewGame(client)
-	checkErr(err)
+	game, err := game.NewGame(client, start)
+	if err != nil {
+		client.Stop()
+		checkErr(err)
+	}
 
 	err = game.Start()
 	checkErr(err)
 }
 
+// importPuzzle returns the puzzle given with the command line flags,
+// nil if no puzzle is given
+func importPuzzle(puzzle, file string) (board.Board, error) {
+	if puzzle != "" {
+		return game.ParsePuzzle(puzzle)
+	} else if file != "" {
+		return game.ImportPuzzle(file)
+	}
+	return nil, nil
+}
+
 func checkErr(err error) {
 	if err != nil {
 		fmt.Println("error:", err.Error())
+		os.Exit(1)
 	}
 }